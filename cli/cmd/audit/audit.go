@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	internalAudit "github.com/OpenLabsHQ/OpenLabs/cli/internal/audit"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+var globalConfig *config.Config
+
+func SetGlobalConfig(cfg *config.Config) {
+	globalConfig = cfg
+}
+
+type auditOptions struct {
+	command   string
+	rangeRef  string
+	blueprint string
+	outcome   string
+	since     string
+	limit     int
+}
+
+func NewAuditCommand() *cobra.Command {
+	var opts auditOptions
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the local record of deploys, destroys, and blueprint changes",
+		Long:  "Show the audit log the CLI keeps of the changes it makes: range deploys and destroys, and blueprint creates, imports, and deletes. Each entry has the time, command, range and blueprint, job ID, and outcome (submitted, succeeded, or failed). The log is off by default; turn it on with 'openlabs config set audit-log true'. It is kept in audit.log in the openlabs directory and rotated at 1 MiB.",
+		Example: `  # Turn the audit log on
+  openlabs config set audit-log true
+
+  # Show the last 20 entries
+  openlabs audit --limit 20
+
+  # Show everything that happened to range lab-1 in the last week
+  openlabs audit --range lab-1 --since 7d
+
+  # Show failed deploys
+  openlabs audit --command "range deploy" --outcome failed`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAudit(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.command, "command", "", "only show entries whose command contains this text (e.g. \"range deploy\")")
+	cmd.Flags().StringVar(&opts.rangeRef, "range", "", "only show entries for this range name or ID")
+	cmd.Flags().StringVar(&opts.blueprint, "blueprint", "", "only show entries for this blueprint name or ID")
+	cmd.Flags().StringVar(&opts.outcome, "outcome", "", "only show entries with this outcome (submitted, succeeded, failed)")
+	cmd.Flags().StringVar(&opts.since, "since", "", "only show entries newer than this (e.g. 24h, 7d)")
+	cmd.Flags().IntVar(&opts.limit, "limit", 0, "show only the most recent N matching entries")
+
+	return cmd
+}
+
+func runAudit(opts auditOptions) error {
+	switch opts.outcome {
+	case "", internalAudit.OutcomeSubmitted, internalAudit.OutcomeSucceeded, internalAudit.OutcomeFailed:
+	default:
+		return fmt.Errorf("invalid outcome: %s (valid: submitted, succeeded, failed)", opts.outcome)
+	}
+
+	if opts.limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	var cutoff time.Time
+	if opts.since != "" {
+		d, err := utils.ParseDuration(opts.since)
+		if err != nil {
+			return err
+		}
+		cutoff = time.Now().Add(-d)
+	}
+
+	entries, err := internalAudit.Load()
+	if err != nil {
+		return err
+	}
+
+	matched := []internalAudit.Entry{}
+	for _, entry := range entries {
+		if matchesAuditFilter(entry, opts, cutoff) {
+			matched = append(matched, entry)
+		}
+	}
+
+	if opts.limit > 0 && len(matched) > opts.limit {
+		matched = matched[len(matched)-opts.limit:]
+	}
+
+	if len(matched) == 0 && output.IsTable(globalConfig.Format) {
+		if !globalConfig.AuditLog && len(entries) == 0 {
+			fmt.Println("The audit log is off. Turn it on with 'openlabs config set audit-log true'.")
+		} else {
+			fmt.Println("No audit log entries found.")
+		}
+		return nil
+	}
+
+	return output.Display(matched, globalConfig.Format)
+}
+
+func matchesAuditFilter(entry internalAudit.Entry, opts auditOptions, cutoff time.Time) bool {
+	if !cutoff.IsZero() && entry.Time.Before(cutoff) {
+		return false
+	}
+	if opts.command != "" && !strings.Contains(strings.ToLower(entry.Command), strings.ToLower(opts.command)) {
+		return false
+	}
+	if opts.rangeRef != "" && !strings.EqualFold(entry.RangeName, opts.rangeRef) && strconv.Itoa(entry.RangeID) != opts.rangeRef {
+		return false
+	}
+	if opts.blueprint != "" && !strings.EqualFold(entry.BlueprintName, opts.blueprint) && strconv.Itoa(entry.BlueprintID) != opts.blueprint {
+		return false
+	}
+	if opts.outcome != "" && entry.Outcome != opts.outcome {
+		return false
+	}
+	return true
+}
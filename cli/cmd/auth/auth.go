@@ -18,6 +18,8 @@ func NewAuthCommand() *cobra.Command {
 	cmd.AddCommand(newWhoamiCommand())
 	cmd.AddCommand(newPasswordCommand())
 	cmd.AddCommand(newSecretsCommand())
+	cmd.AddCommand(newRotateCommand())
+	cmd.AddCommand(newTokenCommand())
 
 	return cmd
 }
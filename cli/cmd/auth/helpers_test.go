@@ -0,0 +1,18 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+// newTestConfig returns a config for a fake API served by handler and makes it the package's config.
+func newTestConfig(t *testing.T, handler http.HandlerFunc) *config.Config {
+	t.Helper()
+
+	globalConfig = testutil.FakeAPI(t, handler)
+	t.Cleanup(func() { globalConfig = nil })
+	return globalConfig
+}
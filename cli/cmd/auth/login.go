@@ -2,32 +2,52 @@ package auth
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
 func newLoginCommand() *cobra.Command {
 	var email, password string
+	var force bool
 
 	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Login to OpenLabs",
 		Long:  "Authenticate with OpenLabs API and store credentials securely.",
+		Example: `  # Log in interactively
+  openlabs auth login
+
+  # Supply the email and be prompted only for the password
+  openlabs auth login --email student@example.com
+
+  # Sign in again even though the stored session is still valid
+  openlabs auth login --force`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLogin(email, password)
+			return runLogin(email, password, force)
 		},
 	}
 
 	cmd.Flags().StringVarP(&email, "email", "e", "", "email address")
 	cmd.Flags().StringVarP(&password, "password", "p", "", "password")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "log in again even if the stored session is still valid")
 
 	return cmd
 }
 
-func runLogin(email, password string) error {
+func runLogin(email, password string, force bool) error {
+	if !force {
+		if user := currentSessionUser(getClient()); user != nil {
+			progress.ShowInfo(fmt.Sprintf("Already logged in as %s; use --force to re-authenticate", user.Email))
+			return nil
+		}
+	}
+
 	if email == "" {
 		var err error
 		email, err = utils.PromptString("Email")
@@ -50,17 +70,34 @@ func runLogin(email, password string) error {
 
 	apiClient := getClient()
 
-	spinner := progress.NewSpinner("Authenticating...")
-	spinner.Start()
+	return progress.WithSpinnerReport("Authenticating...", "Authentication failed", func() (string, error) {
+		return "Successfully logged in", apiClient.Login(email, password)
+	})
+}
 
-	err := apiClient.Login(email, password)
-	spinner.Stop()
+// tokenExpiryLeeway allows for the local clock differing from the server's when checking a token's
+// exp claim.
+const tokenExpiryLeeway = time.Minute
 
+// currentSessionUser returns the user of the stored session, or nil when there is no session or it is
+// no longer valid. A token whose exp claim is past, or within tokenExpiryLeeway of it, is treated as
+// expired without asking the server: by the server's clock it may already have run out, and it would
+// soon anyway. Any other token is checked with the server.
+func currentSessionUser(apiClient *client.Client) *client.UserInfo {
+	if !apiClient.IsAuthenticated() {
+		return nil
+	}
+
+	if expiry, ok := client.TokenExpiry(globalConfig.Token()); ok && time.Now().Add(tokenExpiryLeeway).After(expiry) {
+		logger.Debug("Stored token expires at %s, within %s of now", expiry, tokenExpiryLeeway)
+		return nil
+	}
+
+	user, err := apiClient.GetUserInfo()
 	if err != nil {
-		progress.ShowError("Authentication failed")
-		return err
+		logger.Debug("Stored session is not valid: %v", err)
+		return nil
 	}
 
-	progress.ShowSuccess("Successfully logged in")
-	return nil
+	return user
 }
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+// testJWT returns an unsigned token whose exp claim is expiry.
+func testJWT(expiry time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"1","exp":%d}`, expiry.Unix())))
+	return "eyJhbGciOiJIUzI1NiJ9." + payload + ".signature"
+}
+
+func TestLoginReusesValidSession(t *testing.T) {
+	valid := testJWT(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name          string
+		token         string
+		serverAccepts bool
+		force         bool
+		wantLogin     bool
+		wantUserCheck bool
+	}{
+		{name: "valid session", token: valid, serverAccepts: true, wantUserCheck: true},
+		{name: "valid session with force", token: valid, serverAccepts: true, force: true, wantLogin: true},
+		{name: "expired token", token: testJWT(time.Now().Add(-time.Minute)), serverAccepts: true, wantLogin: true},
+		{name: "token expiring within the leeway", token: testJWT(time.Now().Add(tokenExpiryLeeway / 2)), serverAccepts: true, wantLogin: true},
+		{name: "token expiring after the leeway", token: testJWT(time.Now().Add(2 * tokenExpiryLeeway)), serverAccepts: true, wantUserCheck: true},
+		{name: "token rejected by the server", token: valid, wantLogin: true, wantUserCheck: true},
+		{name: "opaque token checked with the server", token: "opaque-token", serverAccepts: true, wantUserCheck: true},
+		{name: "no token", wantLogin: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logins, userChecks atomic.Int32
+			cfg := newTestConfig(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v1/users/me":
+					userChecks.Add(1)
+					if !tt.serverAccepts {
+						testutil.RespondJSON(w, http.StatusUnauthorized, `{"detail":"Could not validate credentials"}`)
+						return
+					}
+					testutil.RespondJSON(w, http.StatusOK, `{"name":"Test","email":"test@example.com","admin":false}`)
+				case "/api/v1/auth/login":
+					logins.Add(1)
+					http.SetCookie(w, &http.Cookie{Name: "token", Value: "new-token"})
+					http.SetCookie(w, &http.Cookie{Name: "enc_key", Value: "new-key"})
+					testutil.RespondJSON(w, http.StatusOK, `{"success":true}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			})
+			cfg.AuthToken = tt.token
+
+			if err := runLogin("test@example.com", "password", tt.force); err != nil {
+				t.Fatalf("runLogin() error = %v", err)
+			}
+
+			if got := logins.Load() > 0; got != tt.wantLogin {
+				t.Errorf("logged in = %v, want %v", got, tt.wantLogin)
+			}
+			if got := userChecks.Load() > 0; got != tt.wantUserCheck {
+				t.Errorf("checked the session with the server = %v, want %v", got, tt.wantUserCheck)
+			}
+
+			wantToken := tt.token
+			if tt.wantLogin {
+				wantToken = "new-token"
+			}
+			if cfg.AuthToken != wantToken {
+				t.Errorf("token = %q, want %q", cfg.AuthToken, wantToken)
+			}
+		})
+	}
+}
@@ -20,17 +20,7 @@ func newLogoutCommand() *cobra.Command {
 func runLogout() error {
 	apiClient := getClient()
 
-	spinner := progress.NewSpinner("Logging out...")
-	spinner.Start()
-
-	err := apiClient.Logout()
-	spinner.Stop()
-
-	if err != nil {
-		progress.ShowError("Logout failed")
-		return err
-	}
-
-	progress.ShowSuccess("Successfully logged out")
-	return nil
+	return progress.WithSpinnerReport("Logging out...", "Logout failed", func() (string, error) {
+		return "Successfully logged out", apiClient.Logout()
+	})
 }
@@ -50,17 +50,7 @@ func runPasswordChange() error {
 		return fmt.Errorf("passwords do not match")
 	}
 
-	spinner := progress.NewSpinner("Updating password...")
-	spinner.Start()
-
-	err = apiClient.UpdatePassword(currentPassword, newPassword)
-	spinner.Stop()
-
-	if err != nil {
-		progress.ShowError("Password update failed")
-		return err
-	}
-
-	progress.ShowSuccess("Password updated successfully")
-	return nil
+	return progress.WithSpinnerReport("Updating password...", "Password update failed", func() (string, error) {
+		return "Password updated successfully", apiClient.UpdatePassword(currentPassword, newPassword)
+	})
 }
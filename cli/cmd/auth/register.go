@@ -80,18 +80,13 @@ func runRegister(cmd *cobra.Command, name, email, password string) error {
 
 	apiClient := getClient()
 
-	spinner := progress.NewSpinner("Creating account...")
-	spinner.Start()
-
-	err := apiClient.Register(name, email, password)
-	spinner.Stop()
-
+	err := progress.WithSpinnerReport("Creating account...", "Registration failed", func() (string, error) {
+		return "Account created successfully", apiClient.Register(name, email, password)
+	})
 	if err != nil {
-		progress.ShowError("Registration failed")
 		return err
 	}
 
-	progress.ShowSuccess("Account created successfully")
 	progress.ShowInfo("You can now login with 'openlabs auth login'")
 	return nil
 }
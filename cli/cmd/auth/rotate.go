@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+type rotateProvider struct {
+	name      string
+	label     string
+	configure func(*client.Client, bool) (bool, error)
+}
+
+var rotateProviders = []rotateProvider{
+	{name: "aws", label: "AWS", configure: configureAWS},
+	{name: "azure", label: "Azure", configure: configureAzure},
+	{name: "gcp", label: "GCP", configure: configureGCP},
+}
+
+type RotateResult struct {
+	Provider string `json:"provider"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+func newRotateCommand() *cobra.Command {
+	var (
+		provider       string
+		skipValidation bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotate cloud provider credentials",
+		Long:  "Walk through replacing the credentials of every configured cloud provider, or just one with --provider.",
+		Example: `  # Rotate credentials for every configured provider
+  openlabs auth rotate
+
+  # Rotate only the AWS credentials
+  openlabs auth rotate --provider aws`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRotate(provider, skipValidation)
+		},
+	}
+
+	cmd.Flags().StringVar(&provider, "provider", "", "rotate only this provider (aws, azure, gcp)")
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "save new credentials without asking the server to verify them")
+
+	return cmd
+}
+
+func runRotate(provider string, skipValidation bool) error {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider != "" && provider != "aws" && provider != "azure" && provider != "gcp" {
+		return fmt.Errorf("invalid provider: %s (valid: aws, azure, gcp)", provider)
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	secrets, err := apiClient.GetUserSecrets()
+	if err != nil {
+		return fmt.Errorf("failed to get secrets status: %w", err)
+	}
+
+	configured := map[string]bool{
+		"aws":   secrets.AWS.HasCredentials,
+		"azure": secrets.Azure.HasCredentials,
+		"gcp":   secrets.GCP.HasCredentials,
+	}
+
+	if output.IsTable(globalConfig.Format) {
+		displaySecretsTable(secrets)
+		fmt.Println()
+	}
+
+	var selected []rotateProvider
+	for _, p := range rotateProviders {
+		if provider == p.name || (provider == "" && configured[p.name]) {
+			selected = append(selected, p)
+		}
+	}
+
+	if len(selected) == 0 {
+		progress.ShowInfo("No credentials are configured, so there is nothing to rotate")
+		return nil
+	}
+
+	var results []RotateResult
+	failed := 0
+
+	for _, p := range selected {
+		if !configured[p.name] {
+			progress.ShowInfo(fmt.Sprintf("%s credentials are not configured yet; they will be added", p.label))
+		}
+
+		progress.ShowInfo(fmt.Sprintf("Rotating %s credentials", p.label))
+
+		saved, err := p.configure(apiClient, skipValidation)
+		result := RotateResult{Provider: p.label}
+		switch {
+		case err != nil:
+			failed++
+			result.Status = "failed"
+			result.Error = err.Error()
+			progress.ShowError(fmt.Sprintf("Failed to rotate %s credentials: %v", p.label, err))
+		case saved:
+			result.Status = "rotated"
+		default:
+			result.Status = "skipped"
+		}
+		results = append(results, result)
+
+		fmt.Println()
+	}
+
+	if err := output.Display(results, globalConfig.Format); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to rotate credentials for %d of %d providers", failed, len(results))
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -16,6 +17,13 @@ func newSecretsCommand() *cobra.Command {
 		Use:   "secrets",
 		Short: "Manage cloud provider credentials",
 		Long:  "View and configure cloud provider credentials for deploying ranges.",
+		Example: `  # Show which providers have credentials configured
+  openlabs auth secrets
+
+  # Configure credentials interactively
+  openlabs auth secrets aws
+  openlabs auth secrets azure
+  openlabs auth secrets gcp`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSecretsStatus()
 		},
@@ -23,30 +31,66 @@ func newSecretsCommand() *cobra.Command {
 
 	cmd.AddCommand(newSecretsAWSCommand())
 	cmd.AddCommand(newSecretsAzureCommand())
+	cmd.AddCommand(newSecretsGCPCommand())
 
 	return cmd
 }
 
 func newSecretsAWSCommand() *cobra.Command {
-	return &cobra.Command{
+	var skipValidation bool
+
+	cmd := &cobra.Command{
 		Use:   "aws",
 		Short: "Configure AWS credentials",
 		Long:  "Set up AWS access credentials for deploying ranges to AWS.",
+		Example: `  # Use credentials detected from the local AWS configuration, or prompt for them
+  openlabs auth secrets aws`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConfigureAWS()
+			return runConfigureAWS(skipValidation)
 		},
 	}
+
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "save the credentials without asking the server to verify them")
+
+	return cmd
 }
 
 func newSecretsAzureCommand() *cobra.Command {
-	return &cobra.Command{
+	var skipValidation bool
+
+	cmd := &cobra.Command{
 		Use:   "azure",
 		Short: "Configure Azure credentials",
 		Long:  "Set up Azure service principal credentials for deploying ranges to Azure.",
+		Example: `  # Prompt for the client ID, client secret, tenant ID, and subscription ID
+  openlabs auth secrets azure`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConfigureAzure()
+			return runConfigureAzure(skipValidation)
 		},
 	}
+
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "save the credentials without asking the server to verify them")
+
+	return cmd
+}
+
+func newSecretsGCPCommand() *cobra.Command {
+	var skipValidation bool
+
+	cmd := &cobra.Command{
+		Use:   "gcp",
+		Short: "Configure GCP credentials",
+		Long:  "Set up a GCP service account key for deploying ranges to GCP. The key is taken from GOOGLE_APPLICATION_CREDENTIALS when set, or from a key file path or pasted JSON.",
+		Example: `  # Use the key named by GOOGLE_APPLICATION_CREDENTIALS, or prompt for one
+  openlabs auth secrets gcp`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigureGCP(skipValidation)
+		},
+	}
+
+	cmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "save the credentials without asking the server to verify them")
+
+	return cmd
 }
 
 func runSecretsStatus() error {
@@ -61,12 +105,12 @@ func runSecretsStatus() error {
 		return fmt.Errorf("failed to get secrets status: %w", err)
 	}
 
-	if globalConfig.OutputFormat == "table" {
+	if output.IsTable(globalConfig.Format) {
 		displaySecretsTable(secrets)
-		return nil
+		return output.WriteAlso(secrets)
 	}
 
-	return output.Display(secrets, globalConfig.OutputFormat)
+	return output.Display(secrets, globalConfig.Format)
 }
 
 func displaySecretsTable(secrets *client.UserSecretResponse) {
@@ -85,7 +129,13 @@ func displaySecretsTable(secrets *client.UserSecretResponse) {
 	}
 	fmt.Println()
 
-	if !secrets.AWS.HasCredentials || !secrets.Azure.HasCredentials {
+	fmt.Printf("GCP:   %s", getStatusText(secrets.GCP.HasCredentials))
+	if secrets.GCP.HasCredentials && secrets.GCP.CreatedAt != nil {
+		fmt.Printf(" (configured %s)", secrets.GCP.CreatedAt.Format("2006-01-02"))
+	}
+	fmt.Println()
+
+	if !secrets.AWS.HasCredentials || !secrets.Azure.HasCredentials || !secrets.GCP.HasCredentials {
 		fmt.Println()
 		fmt.Println("Configure credentials with:")
 		if !secrets.AWS.HasCredentials {
@@ -94,6 +144,9 @@ func displaySecretsTable(secrets *client.UserSecretResponse) {
 		if !secrets.Azure.HasCredentials {
 			fmt.Println("  openlabs auth secrets azure")
 		}
+		if !secrets.GCP.HasCredentials {
+			fmt.Println("  openlabs auth secrets gcp")
+		}
 	}
 }
 
@@ -104,13 +157,20 @@ func getStatusText(hasCredentials bool) string {
 	return "✗ Not configured"
 }
 
-func runConfigureAWS() error {
+func runConfigureAWS(skipValidation bool) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
+	_, err := configureAWS(apiClient, skipValidation)
+	return err
+}
+
+// configureAWS prompts for AWS credentials, offering any found locally, verifies them unless
+// skipValidation is set, and saves them. It reports whether the credentials were saved.
+func configureAWS(apiClient *client.Client, skipValidation bool) (bool, error) {
 	var accessKey, secretKey string
 	var err error
 
@@ -124,14 +184,14 @@ func runConfigureAWS() error {
 
 		useDetected, err := utils.PromptConfirm("Use these credentials?")
 		if err != nil {
-			return fmt.Errorf("failed to read confirmation: %w", err)
+			return false, fmt.Errorf("failed to read confirmation: %w", err)
 		}
 
 		if useDetected {
 			if detectedCreds.AccessKeyID == "" {
 				selectedCreds, err := utils.SelectAWSProfile()
 				if err != nil {
-					return fmt.Errorf("failed to select profile: %w", err)
+					return false, fmt.Errorf("failed to select profile: %w", err)
 				}
 				accessKey = selectedCreds.AccessKeyID
 				secretKey = selectedCreds.SecretAccessKey
@@ -147,92 +207,229 @@ func runConfigureAWS() error {
 	if accessKey == "" {
 		accessKey, err = utils.PromptString("AWS Access Key ID")
 		if err != nil {
-			return fmt.Errorf("failed to read access key: %w", err)
+			return false, fmt.Errorf("failed to read access key: %w", err)
 		}
 
 		if err := utils.ValidateNonEmpty(accessKey, "access key"); err != nil {
-			return err
+			return false, err
 		}
 
 		secretKey, err = utils.PromptPassword("AWS Secret Access Key")
 		if err != nil {
-			return fmt.Errorf("failed to read secret key: %w", err)
+			return false, fmt.Errorf("failed to read secret key: %w", err)
 		}
 
 		if err := utils.ValidateNonEmpty(secretKey, "secret key"); err != nil {
-			return err
+			return false, err
 		}
 	}
 
-	spinner := progress.NewSpinner("Saving AWS credentials...")
-	spinner.Start()
-
-	err = apiClient.UpdateAWSSecrets(accessKey, secretKey)
-	spinner.Stop()
+	fmt.Println()
+	fmt.Printf("Access key: %s\n", utils.MaskSecret(accessKey))
+	fmt.Printf("Secret key: %s\n", utils.MaskSecret(secretKey))
 
+	confirmed, err := utils.PromptConfirm("Save these AWS credentials?")
 	if err != nil {
-		progress.ShowError("Failed to save AWS credentials")
-		return err
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if !confirmed {
+		progress.ShowInfo("AWS credentials not saved")
+		return false, nil
+	}
+
+	if !skipValidation {
+		creds := client.AWSSecrets{AccessKey: accessKey, SecretKey: secretKey}
+		if err := verifyCredentials(apiClient, "aws", "AWS", creds); err != nil {
+			return false, err
+		}
 	}
 
-	progress.ShowSuccess("AWS credentials saved successfully")
-	return nil
+	err = progress.WithSpinnerReport("Saving AWS credentials...", "Failed to save AWS credentials", func() (string, error) {
+		return "AWS credentials saved successfully", apiClient.UpdateAWSSecrets(accessKey, secretKey)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-func runConfigureAzure() error {
+func runConfigureAzure(skipValidation bool) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
+	_, err := configureAzure(apiClient, skipValidation)
+	return err
+}
+
+// configureAzure prompts for Azure service principal credentials, verifies them unless skipValidation
+// is set, and saves them. It reports whether the credentials were saved.
+func configureAzure(apiClient *client.Client, skipValidation bool) (bool, error) {
 	clientID, err := utils.PromptString("Client ID")
 	if err != nil {
-		return fmt.Errorf("failed to read client ID: %w", err)
+		return false, fmt.Errorf("failed to read client ID: %w", err)
 	}
 
 	if err := utils.ValidateNonEmpty(clientID, "client ID"); err != nil {
-		return err
+		return false, err
 	}
 
 	clientSecret, err := utils.PromptPassword("Client Secret")
 	if err != nil {
-		return fmt.Errorf("failed to read client secret: %w", err)
+		return false, fmt.Errorf("failed to read client secret: %w", err)
 	}
 
 	if err := utils.ValidateNonEmpty(clientSecret, "client secret"); err != nil {
-		return err
+		return false, err
 	}
 
 	tenantID, err := utils.PromptString("Tenant ID")
 	if err != nil {
-		return fmt.Errorf("failed to read tenant ID: %w", err)
+		return false, fmt.Errorf("failed to read tenant ID: %w", err)
 	}
 
 	if err := utils.ValidateNonEmpty(tenantID, "tenant ID"); err != nil {
-		return err
+		return false, err
 	}
 
 	subscriptionID, err := utils.PromptString("Subscription ID")
 	if err != nil {
-		return fmt.Errorf("failed to read subscription ID: %w", err)
+		return false, fmt.Errorf("failed to read subscription ID: %w", err)
 	}
 
 	if err := utils.ValidateNonEmpty(subscriptionID, "subscription ID"); err != nil {
-		return err
+		return false, err
 	}
 
-	spinner := progress.NewSpinner("Saving Azure credentials...")
-	spinner.Start()
+	fmt.Println()
+	fmt.Printf("Client ID:       %s\n", clientID)
+	fmt.Printf("Client secret:   %s\n", utils.MaskSecret(clientSecret))
+	fmt.Printf("Tenant ID:       %s\n", tenantID)
+	fmt.Printf("Subscription ID: %s\n", subscriptionID)
 
-	err = apiClient.UpdateAzureSecrets(clientID, clientSecret, tenantID, subscriptionID)
-	spinner.Stop()
+	confirmed, err := utils.PromptConfirm("Save these Azure credentials?")
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if !confirmed {
+		progress.ShowInfo("Azure credentials not saved")
+		return false, nil
+	}
+
+	if !skipValidation {
+		creds := client.AzureSecrets{
+			ClientID:       clientID,
+			ClientSecret:   clientSecret,
+			TenantID:       tenantID,
+			SubscriptionID: subscriptionID,
+		}
+		if err := verifyCredentials(apiClient, "azure", "Azure", creds); err != nil {
+			return false, err
+		}
+	}
 
+	err = progress.WithSpinnerReport("Saving Azure credentials...", "Failed to save Azure credentials", func() (string, error) {
+		return "Azure credentials saved successfully", apiClient.UpdateAzureSecrets(clientID, clientSecret, tenantID, subscriptionID)
+	})
 	if err != nil {
-		progress.ShowError("Failed to save Azure credentials")
-		return err
+		return false, err
 	}
+	return true, nil
+}
+
+func runConfigureGCP(skipValidation bool) error {
+	apiClient := getClient()
 
-	progress.ShowSuccess("Azure credentials saved successfully")
-	return nil
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	_, err := configureGCP(apiClient, skipValidation)
+	return err
+}
+
+// configureGCP prompts for a GCP service account key, offering the one named by
+// GOOGLE_APPLICATION_CREDENTIALS, verifies it unless skipValidation is set, and saves it. It reports
+// whether the credentials were saved.
+func configureGCP(apiClient *client.Client, skipValidation bool) (bool, error) {
+	var creds *utils.GCPCredentials
+
+	detectedCreds, detectErr := utils.DetectGCPCredentials()
+	switch {
+	case detectErr != nil:
+		progress.ShowWarning(fmt.Sprintf("Ignoring GOOGLE_APPLICATION_CREDENTIALS: %v", detectErr))
+	case detectedCreds != nil:
+		progress.ShowInfo(fmt.Sprintf("Found GCP credentials for %s in %s", detectedCreds.ClientEmail, detectedCreds.Source))
+
+		useDetected, err := utils.PromptConfirm("Use these credentials?")
+		if err != nil {
+			return false, fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if useDetected {
+			creds = detectedCreds
+		}
+	default:
+		progress.ShowInfo("No GCP credentials found automatically. Enter manually:")
+	}
+
+	if creds == nil {
+		var err error
+		creds, err = utils.PromptGCPServiceAccount("Service account key file (or paste the JSON)")
+		if err != nil {
+			return false, err
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Project ID:      %s\n", creds.ProjectID)
+	fmt.Printf("Service account: %s\n", creds.ClientEmail)
+
+	confirmed, err := utils.PromptConfirm("Save these GCP credentials?")
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if !confirmed {
+		progress.ShowInfo("GCP credentials not saved")
+		return false, nil
+	}
+
+	if !skipValidation {
+		secrets := client.GCPSecrets{ProjectID: creds.ProjectID, ServiceAccountJSON: creds.JSON}
+		if err := verifyCredentials(apiClient, "gcp", "GCP", secrets); err != nil {
+			return false, err
+		}
+	}
+
+	err = progress.WithSpinnerReport("Saving GCP credentials...", "Failed to save GCP credentials", func() (string, error) {
+		return "GCP credentials saved successfully", apiClient.UpdateGCPSecrets(creds.ProjectID, creds.JSON)
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// verifyCredentials asks the server to check credentials before they are saved. Servers without a
+// validation endpoint are reported and skipped rather than treated as a failure.
+func verifyCredentials(apiClient *client.Client, provider, label string, creds interface{}) error {
+	err := progress.WithSpinner(fmt.Sprintf("Verifying %s credentials...", label), func() error {
+		return apiClient.ValidateSecrets(provider, creds)
+	})
+	switch {
+	case err == nil:
+		progress.ShowSuccess(fmt.Sprintf("%s credentials verified", label))
+		return nil
+	case errors.Is(err, client.ErrNotSupported):
+		progress.ShowWarning("This server cannot verify credentials; saving them unverified")
+		return nil
+	case errors.Is(err, client.ErrEncryptionKeyInvalid):
+		return err
+	case errors.Is(err, client.ErrCredentialsRejected):
+		progress.ShowError(fmt.Sprintf("%s credentials rejected (check permissions)", label))
+		return fmt.Errorf("%w (use --skip-validation to save them anyway)", err)
+	default:
+		return fmt.Errorf("failed to verify %s credentials: %w (use --skip-validation to save them anyway)", label, err)
+	}
 }
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
@@ -26,15 +28,19 @@ func runStatus() error {
 	}
 
 	if apiClient.IsAuthenticated() {
-		if err := apiClient.Ping(); err != nil {
+		latency, err := apiClient.PingWithLatency()
+		if err != nil {
 			status["api_connectivity"] = "failed"
 			status["error"] = err.Error()
+		} else if output.IsTable(globalConfig.Format) {
+			status["api_connectivity"] = fmt.Sprintf("ok (%dms)", latency.Milliseconds())
 		} else {
 			status["api_connectivity"] = "ok"
+			status["api_latency_ms"] = latency.Milliseconds()
 		}
 	} else {
 		status["api_connectivity"] = "not checked (not authenticated)"
 	}
 
-	return output.Display(status, globalConfig.OutputFormat)
+	return output.Display(status, globalConfig.Format)
 }
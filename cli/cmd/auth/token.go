@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+func newTokenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage short-lived scoped tokens",
+		Long:  "Create and revoke short-lived tokens limited to specific scopes, for automation such as CI pipelines. Tokens are printed, never saved; supply one to the CLI through the " + config.APIKeyEnv + " environment variable.",
+	}
+
+	cmd.AddCommand(newTokenCreateCommand())
+	cmd.AddCommand(newTokenRevokeCommand())
+
+	return cmd
+}
+
+func newTokenCreateCommand() *cobra.Command {
+	var scopes []string
+	var ttl time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a scoped token",
+		Long:  "Request a token limited to the given scopes that expires after --ttl. The token is shown once and is not stored.",
+		Example: `  # Create a one-hour token that can only deploy ranges
+  openlabs auth token create --scope deploy --ttl 1h
+
+  # Use it in a CI job
+  export ` + config.APIKeyEnv + `=$(openlabs auth token create --scope deploy --ttl 1h --query token)`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTokenCreate(scopes, ttl)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&scopes, "scope", nil, "scope the token is limited to (repeatable)")
+	cmd.Flags().DurationVar(&ttl, "ttl", time.Hour, "how long the token stays valid")
+	_ = cmd.MarkFlagRequired("scope")
+
+	return cmd
+}
+
+func newTokenRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "revoke [token-id]",
+		Short:   "Revoke a scoped token",
+		Long:    "Invalidate a scoped token before it expires.",
+		Example: `  openlabs auth token revoke 3f2a9c`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTokenRevoke(args[0])
+		},
+	}
+}
+
+func runTokenCreate(scopes []string, ttl time.Duration) error {
+	if ttl < time.Second {
+		return fmt.Errorf("--ttl must be at least 1s")
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	token, err := apiClient.CreateScopedToken(scopes, ttl)
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return fmt.Errorf("scoped tokens are not supported by this server")
+		}
+		return err
+	}
+
+	if !output.IsTable(globalConfig.Format) {
+		return output.Display(token, globalConfig.Format)
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Created token %s (expires %s)", token.ID, output.FormatTime(token.ExpiresAt)))
+	fmt.Println()
+	fmt.Println(token.Token)
+	fmt.Println()
+	progress.ShowInfo(fmt.Sprintf("This token will not be shown again. Set %s to use it, and revoke it with 'openlabs auth token revoke %s'", config.APIKeyEnv, token.ID))
+
+	return output.WriteAlso(token)
+}
+
+func runTokenRevoke(id string) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	if err := apiClient.RevokeScopedToken(id); err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return fmt.Errorf("scoped tokens are not supported by this server")
+		}
+		return err
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Revoked token %s", id))
+	return nil
+}
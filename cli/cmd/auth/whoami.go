@@ -1,35 +1,119 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
 func newWhoamiCommand() *cobra.Command {
-	return &cobra.Command{
+	var refresh bool
+
+	cmd := &cobra.Command{
 		Use:   "whoami",
 		Short: "Show current user information",
 		Long:  "Display information about the currently authenticated user.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runWhoami()
+			return runWhoami(refresh)
 		},
 	}
+
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "refresh the session token before showing user information")
+
+	return cmd
 }
 
-func runWhoami() error {
+func runWhoami(refresh bool) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
+	if refresh {
+		if err := refreshSession(apiClient); err != nil {
+			return err
+		}
+	}
+
 	userInfo, err := apiClient.GetUserInfo()
 	if err != nil {
 		return fmt.Errorf("failed to get user information: %w", err)
 	}
 
-	return output.Display(userInfo, globalConfig.OutputFormat)
+	if err := output.Display(userInfo, globalConfig.Format); err != nil {
+		return err
+	}
+
+	if output.IsTable(globalConfig.Format) {
+		displayQuota(apiClient)
+	}
+
+	return nil
+}
+
+func displayQuota(apiClient *client.Client) {
+	quota, err := apiClient.GetQuota()
+	if err != nil {
+		return
+	}
+
+	fmt.Printf("Ranges: %s\n", formatQuotaUsage(quota.UsedRanges, quota.MaxRanges))
+	fmt.Printf("Hosts:  %s\n", formatQuotaUsage(quota.UsedHosts, quota.MaxHosts))
+}
+
+func formatQuotaUsage(used int, max *int) string {
+	if max == nil {
+		return fmt.Sprintf("%d used (no limit)", used)
+	}
+	return fmt.Sprintf("%d of %d used", used, *max)
+}
+
+func refreshSession(apiClient *client.Client) error {
+	expiry, err := apiClient.RefreshToken()
+	if err == nil {
+		if expiry != nil {
+			progress.ShowSuccess(fmt.Sprintf("Session refreshed (expires %s)", output.FormatTime(*expiry)))
+		} else {
+			progress.ShowSuccess("Session refreshed")
+		}
+		return nil
+	}
+
+	if errors.Is(err, client.ErrNotSupported) {
+		if _, err := apiClient.GetUserInfo(); err != nil {
+			if errors.Is(err, client.ErrUnauthorized) {
+				return promptRelogin("Session token is no longer valid")
+			}
+			return fmt.Errorf("failed to check session: %w", err)
+		}
+		progress.ShowInfo("Token refresh is not supported by this server; current session is valid")
+		return nil
+	}
+
+	return promptRelogin(fmt.Sprintf("Failed to refresh session: %v", err))
+}
+
+func promptRelogin(reason string) error {
+	progress.ShowError(reason)
+
+	if !utils.IsInteractive() {
+		return fmt.Errorf("session expired. Run 'openlabs auth login' to sign in again")
+	}
+
+	relogin, err := utils.PromptConfirm("Log in again now?")
+	if err != nil {
+		return err
+	}
+	if !relogin {
+		return fmt.Errorf("session expired. Run 'openlabs auth login' to sign in again")
+	}
+
+	return runLogin("", "", true)
 }
@@ -11,11 +11,19 @@ func NewBlueprintsCommand() *cobra.Command {
 		Long:  "Create, list, and manage range blueprint templates.",
 	}
 
+	cmd.PersistentFlags().BoolVar(&selectMode, "select", false, "choose the blueprint from a menu")
+
 	cmd.AddCommand(newListCommand())
 	cmd.AddCommand(newShowCommand())
+	cmd.AddCommand(newVersionsCommand())
+	cmd.AddCommand(newCatalogCommand())
+	cmd.AddCommand(newHostsCommand())
+	cmd.AddCommand(newStatsCommand())
 	cmd.AddCommand(newCreateCommand())
+	cmd.AddCommand(newCreateAllCommand())
 	cmd.AddCommand(newDeleteCommand())
 	cmd.AddCommand(newValidateCommand())
+	cmd.AddCommand(newPreviewCommand())
 	cmd.AddCommand(newExportCommand())
 
 	return cmd
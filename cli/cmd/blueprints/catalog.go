@@ -0,0 +1,222 @@
+package blueprints
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+var errCatalogNotSupported = errors.New("this server does not have a public blueprint catalog")
+
+type catalogOptions struct {
+	provider string
+	tags     []string
+	search   string
+}
+
+// CatalogRow is a catalog entry as listed, with Source telling public blueprints from the user's own
+// shared ones.
+type CatalogRow struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Provider    string   `json:"provider"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	VNC         bool     `json:"vnc"`
+	VPN         bool     `json:"vpn"`
+	Source      string   `json:"source"`
+}
+
+func newCatalogCommand() *cobra.Command {
+	var opts catalogOptions
+
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Browse the public blueprint catalog",
+		Long:  "List the blueprints shared in the server's public catalog. The SOURCE column marks your own shared blueprints as owned. Filter with --provider, --tag (all given tags must match), and --search, which matches the name or description. Import one into your account with 'blueprints catalog get'.",
+		Example: `  # Browse the catalog
+  openlabs blueprints catalog
+
+  # Find AWS blueprints tagged web
+  openlabs blueprints catalog --provider aws --tag web
+
+  # Import blueprint 42 under a new name
+  openlabs blueprints catalog get 42 --name my-web-lab`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCatalog(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.provider, "provider", "", "only show blueprints for this provider")
+	cmd.Flags().StringSliceVar(&opts.tags, "tag", nil, "only show blueprints with this tag (repeatable)")
+	cmd.Flags().StringVar(&opts.search, "search", "", "only show blueprints whose name or description contains this text")
+
+	cmd.AddCommand(newCatalogGetCommand())
+
+	return cmd
+}
+
+func runCatalog(opts catalogOptions) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	entries, err := apiClient.ListPublicBlueprints(client.CatalogFilter{Provider: opts.provider, Tags: opts.tags})
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return errCatalogNotSupported
+		}
+		return err
+	}
+
+	owned := ownedBlueprintIDs(apiClient)
+
+	rows := []CatalogRow{}
+	for _, entry := range entries {
+		if !matchesCatalogFilter(entry, opts) {
+			continue
+		}
+
+		source := "public"
+		if owned[entry.ID] {
+			source = "owned"
+		}
+		rows = append(rows, CatalogRow{
+			ID:          entry.ID,
+			Name:        entry.Name,
+			Provider:    entry.Provider,
+			Description: entry.Description,
+			Tags:        entry.Tags,
+			VNC:         entry.VNC,
+			VPN:         entry.VPN,
+			Source:      source,
+		})
+	}
+
+	if len(rows) == 0 && output.IsTable(globalConfig.Format) {
+		fmt.Println("No catalog blueprints found.")
+		return nil
+	}
+
+	return output.Display(rows, globalConfig.Format)
+}
+
+// matchesCatalogFilter applies the filters locally, for servers that ignore them and for --search,
+// which is never sent.
+func matchesCatalogFilter(entry client.CatalogBlueprint, opts catalogOptions) bool {
+	if opts.provider != "" && !strings.EqualFold(entry.Provider, opts.provider) {
+		return false
+	}
+
+	for _, tag := range opts.tags {
+		if !slices.ContainsFunc(entry.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+
+	if opts.search != "" {
+		needle := strings.ToLower(opts.search)
+		if !strings.Contains(strings.ToLower(entry.Name), needle) && !strings.Contains(strings.ToLower(entry.Description), needle) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ownedBlueprintIDs returns the IDs of the user's own blueprints. The catalog is still worth showing
+// without them, so a failure only means every entry is listed as public.
+func ownedBlueprintIDs(apiClient *client.Client) map[int]bool {
+	owned := make(map[int]bool)
+
+	blueprints, err := apiClient.ListBlueprintRanges()
+	if err != nil {
+		logger.Debug("Cannot mark owned catalog blueprints: %v", err)
+		return owned
+	}
+
+	for _, b := range blueprints {
+		owned[b.ID] = true
+	}
+	return owned
+}
+
+func newCatalogGetCommand() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "get <catalog-id>",
+		Short: "Import a catalog blueprint into your account",
+		Long:  "Copy a blueprint from the public catalog into your account as a new blueprint, which can then be deployed or exported like any other.",
+		Example: `  # Import catalog blueprint 42
+  openlabs blueprints catalog get 42
+
+  # Import it under another name
+  openlabs blueprints catalog get 42 --name my-web-lab`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCatalogGet(args[0], name)
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "name for the imported blueprint (default: the catalog name)")
+
+	return cmd
+}
+
+func runCatalogGet(catalogIDStr, name string) error {
+	catalogID, err := strconv.Atoi(catalogIDStr)
+	if err != nil {
+		return fmt.Errorf("invalid catalog ID: %s", catalogIDStr)
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	blueprint, err := apiClient.GetPublicBlueprint(catalogID)
+	if err != nil {
+		switch {
+		case errors.Is(err, client.ErrNotSupported):
+			return errCatalogNotSupported
+		case errors.Is(err, client.ErrNotFound):
+			return fmt.Errorf("no blueprint with ID %d in the public catalog; run 'openlabs blueprints catalog' to list them", catalogID)
+		}
+		return fmt.Errorf("failed to get catalog blueprint: %w", err)
+	}
+
+	if name != "" {
+		blueprint.Name = name
+	}
+
+	var result *client.BlueprintRangeHeader
+	err = progress.WithSpinnerReport("Importing blueprint...", "Failed to import blueprint", func() (string, error) {
+		var err error
+		result, err = apiClient.CreateBlueprintRange(blueprint)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Imported '%s' as blueprint %d", result.Name, result.ID), nil
+	})
+	if err != nil {
+		recordBlueprintChange("blueprints catalog get", 0, blueprint.Name, err)
+		return err
+	}
+	recordBlueprintChange("blueprints catalog get", result.ID, result.Name, nil)
+
+	return output.Display(result, globalConfig.Format)
+}
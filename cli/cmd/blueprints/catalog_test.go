@@ -0,0 +1,65 @@
+package blueprints
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestCatalogGetImportsPublicBlueprint(t *testing.T) {
+	var created string
+	newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/blueprints/ranges/public/42":
+			testutil.RespondJSON(w, http.StatusOK, `{"id":42,"name":"web-lab","provider":"aws","vpcs":[]}`)
+		case r.Method == "POST" && r.URL.Path == "/api/v1/blueprints/ranges":
+			body, _ := io.ReadAll(r.Body)
+			created = string(body)
+			testutil.RespondJSON(w, http.StatusOK, `{"id":7,"name":"my-web-lab","provider":"aws"}`)
+		default:
+			// The owner-scoped blueprint endpoint 404s for other users' blueprints
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Blueprint range with ID: 42 not found!"}`)
+		}
+	})
+
+	if err := runCatalogGet("42", "my-web-lab"); err != nil {
+		t.Fatalf("runCatalogGet() error = %v", err)
+	}
+	if !strings.Contains(created, `"name":"my-web-lab"`) {
+		t.Errorf("created blueprint %s, want the catalog blueprint under the new name", created)
+	}
+}
+
+func TestCatalogGetErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantErr     error
+		wantErrText string
+	}{
+		{name: "no catalog", status: http.StatusNotFound, body: `{"detail":"Not Found"}`, wantErr: errCatalogNotSupported},
+		{name: "not in the catalog", status: http.StatusNotFound, body: `{"detail":"Blueprint range with ID: 42 not found!"}`, wantErrText: "no blueprint with ID 42 in the public catalog"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				testutil.RespondJSON(w, tt.status, tt.body)
+			})
+
+			err := runCatalogGet("42", "")
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("runCatalogGet() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErrText != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErrText)) {
+				t.Errorf("runCatalogGet() error = %v, want %q", err, tt.wantErrText)
+			}
+		})
+	}
+}
@@ -1,12 +1,30 @@
 package blueprints
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/audit"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
 var globalConfig *config.Config
 
+// selectMode is set by --select: commands pick their blueprint from a menu instead of taking its ID
+// as an argument.
+var selectMode bool
+
+var errVersioningNotSupported = errors.New("blueprint versioning is not supported by this server")
+
+var blueprintFileExtensions = []string{".json", ".yaml", ".yml"}
+
 func SetGlobalConfig(cfg *config.Config) {
 	globalConfig = cfg
 }
@@ -18,3 +36,172 @@ func getClient() *client.Client {
 	}
 	return client.New(globalConfig)
 }
+
+// recordBlueprintChange adds a blueprint create, import, or delete to the audit log. On failure id may
+// be zero, with the blueprint known only by name.
+func recordBlueprintChange(command string, id int, name string, err error) {
+	entry := audit.Entry{Command: command, BlueprintID: id, BlueprintName: name}
+	audit.RecordResult(globalConfig, entry, audit.OutcomeSucceeded, err)
+}
+
+// loadBlueprintFile runs the local validation checks on a blueprint file and returns its parsed contents.
+// The file is rendered as a template with vars first. Keys that aren't part of the blueprint schema are
+// rejected so typos don't silently drop data.
+func loadBlueprintFile(file string, vars map[string]string) (map[string]interface{}, error) {
+	if err := utils.ValidateFileExists(file); err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidateFileExtension(file, blueprintFileExtensions); err != nil {
+		return nil, err
+	}
+
+	data, err := utils.ReadTemplatedFile(file, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var blueprintData map[string]interface{}
+	if err := utils.UnmarshalStructured(file, data, &blueprintData); err != nil {
+		return nil, err
+	}
+
+	if err := utils.CheckKnownFieldsData(file, data, client.BlueprintRange{}); err != nil {
+		return nil, err
+	}
+
+	return blueprintData, nil
+}
+
+// addTemplateVarFlags registers the --var and --var-file flags used to render templated blueprint files.
+func addTemplateVarFlags(cmd *cobra.Command, vars *utils.TemplateVars) {
+	cmd.Flags().StringArrayVar(&vars.Pairs, "var", nil, "template variable as key=value (repeatable)")
+	cmd.Flags().StringVar(&vars.File, "var-file", "", "YAML or JSON file of template variables")
+}
+
+// parseBlueprint converts parsed blueprint file contents into the typed blueprint structure.
+func parseBlueprint(blueprintData map[string]interface{}) (*client.BlueprintRange, error) {
+	data, err := json.Marshal(blueprintData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode blueprint: %w", err)
+	}
+
+	var blueprint client.BlueprintRange
+	if err := json.Unmarshal(data, &blueprint); err != nil {
+		return nil, fmt.Errorf("failed to parse blueprint: %w", err)
+	}
+
+	return &blueprint, nil
+}
+
+func existingBlueprintNames(apiClient *client.Client) (map[string]bool, error) {
+	blueprints, err := apiClient.ListBlueprintRanges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blueprints: %w", err)
+	}
+
+	names := make(map[string]bool, len(blueprints))
+	for _, bp := range blueprints {
+		names[strings.ToLower(bp.Name)] = true
+	}
+	return names, nil
+}
+
+// optionalArg returns the first argument, or "" when there is none.
+func optionalArg(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return ""
+}
+
+// resolveBlueprintID parses a blueprint ID argument. Without one, the blueprint is picked from a menu
+// when --select is given or the session is interactive.
+func resolveBlueprintID(apiClient *client.Client, idStr string) (int, error) {
+	if selectMode && idStr != "" {
+		return 0, fmt.Errorf("--select cannot be combined with a blueprint ID")
+	}
+
+	if idStr != "" {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return 0, fmt.Errorf("invalid blueprint ID: %s", idStr)
+		}
+		return id, nil
+	}
+
+	if !selectMode && !utils.IsInteractive() {
+		return 0, fmt.Errorf("blueprint ID is required")
+	}
+
+	blueprints, err := apiClient.ListBlueprintRanges()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list blueprints: %w", err)
+	}
+
+	if len(blueprints) == 0 {
+		return 0, fmt.Errorf("no blueprints found")
+	}
+
+	options := make([]string, len(blueprints))
+	for i, bp := range blueprints {
+		options[i] = fmt.Sprintf("%s (ID: %d, %s)", bp.Name, bp.ID, bp.Provider)
+	}
+
+	index, err := utils.SelectIndex("Select a blueprint", options)
+	if err != nil {
+		return 0, err
+	}
+	return blueprints[index].ID, nil
+}
+
+// getBlueprintVersion fetches a blueprint at version, or its current state when version is 0. A
+// missing version can come back as ErrNotSupported or ErrNotFound, so the version list is checked to
+// tell it apart from a missing blueprint or a server without versioning.
+func getBlueprintVersion(apiClient *client.Client, blueprintID, version int) (*client.BlueprintRange, error) {
+	if version < 0 {
+		return nil, fmt.Errorf("--version must be a positive number")
+	}
+
+	if version == 0 {
+		blueprint, err := apiClient.GetBlueprintRange(blueprintID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blueprint: %w", err)
+		}
+		return blueprint, nil
+	}
+
+	blueprint, err := apiClient.GetBlueprintVersion(blueprintID, version)
+	if err == nil {
+		return blueprint, nil
+	}
+	if !errors.Is(err, client.ErrNotSupported) && !errors.Is(err, client.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get blueprint: %w", err)
+	}
+
+	versions, err := apiClient.ListBlueprintVersions(blueprintID)
+	if err != nil {
+		return nil, versionsError(apiClient, blueprintID, err)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("blueprint %d has no saved versions", blueprintID)
+	}
+	return nil, fmt.Errorf("blueprint %d has no version %d (latest is %d); run 'openlabs blueprints versions %d' to list them", blueprintID, version, versions[len(versions)-1].Version, blueprintID)
+}
+
+// versionsError explains why the versions of a blueprint could not be listed. A server without
+// versioning and a missing blueprint can both answer with a 404, so the blueprint itself is looked
+// up to tell them apart.
+func versionsError(apiClient *client.Client, blueprintID int, err error) error {
+	if !errors.Is(err, client.ErrNotSupported) && !errors.Is(err, client.ErrNotFound) {
+		return fmt.Errorf("failed to list blueprint versions: %w", err)
+	}
+
+	if _, getErr := apiClient.GetBlueprintRange(blueprintID); errors.Is(getErr, client.ErrNotFound) {
+		return fmt.Errorf("blueprint %d not found", blueprintID)
+	}
+	if errors.Is(err, client.ErrNotSupported) {
+		return errVersioningNotSupported
+	}
+	return fmt.Errorf("failed to list blueprint versions: %w", err)
+}
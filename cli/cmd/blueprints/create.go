@@ -5,54 +5,68 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
 func newCreateCommand() *cobra.Command {
-	return &cobra.Command{
+	var vars utils.TemplateVars
+
+	cmd := &cobra.Command{
 		Use:   "create [file]",
 		Short: "Create a new blueprint",
-		Long:  "Create a new range blueprint from a JSON or YAML file.",
-		Args:  cobra.ExactArgs(1),
+		Long:  "Create a new range blueprint from a JSON or YAML file. The file may use {{.name}} placeholders filled in with --var and --var-file.",
+		Example: `  # Create a blueprint from a file
+  openlabs blueprints create lab.yaml
+
+  # Fill in template placeholders
+  openlabs blueprints create lab.yaml --var team=blue --var cidr=10.2.0.0/16
+  openlabs blueprints create lab.yaml --var-file blue.yaml`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCreate(args[0])
+			return runCreate(args[0], vars)
 		},
 	}
-}
 
-func runCreate(file string) error {
-	apiClient := getClient()
+	addTemplateVarFlags(cmd, &vars)
 
-	if !apiClient.IsAuthenticated() {
-		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
-	}
+	return cmd
+}
 
-	if err := utils.ValidateFileExists(file); err != nil {
+func runCreate(file string, vars utils.TemplateVars) error {
+	values, err := vars.Values()
+	if err != nil {
 		return err
 	}
 
-	if err := utils.ValidateFileExtension(file, []string{".json", ".yaml", ".yml"}); err != nil {
-		return err
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
-	var blueprintData interface{}
-	if err := utils.ReadFileAsStructured(file, &blueprintData); err != nil {
+	blueprintData, err := loadBlueprintFile(file, values)
+	if err != nil {
 		return err
 	}
 
-	spinner := progress.NewSpinner("Creating blueprint...")
-	spinner.Start()
-
-	result, err := apiClient.CreateBlueprintRange(blueprintData)
-	spinner.Stop()
-
+	var result *client.BlueprintRangeHeader
+	err = progress.WithSpinnerReport("Creating blueprint...", "Failed to create blueprint", func() (string, error) {
+		var err error
+		result, err = apiClient.CreateBlueprintRange(blueprintData)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Blueprint created successfully (ID: %d)", result.ID), nil
+	})
 	if err != nil {
-		progress.ShowError("Failed to create blueprint")
+		name, _ := blueprintData["name"].(string)
+		recordBlueprintChange("blueprints create", 0, name, err)
 		return err
 	}
+	recordBlueprintChange("blueprints create", result.ID, result.Name, nil)
 
-	progress.ShowSuccess(fmt.Sprintf("Blueprint created successfully (ID: %d)", result.ID))
-	return output.Display(result, globalConfig.OutputFormat)
+	return output.Display(result, globalConfig.Format)
 }
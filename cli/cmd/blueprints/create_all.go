@@ -0,0 +1,189 @@
+package blueprints
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/concurrency"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+func newCreateAllCommand() *cobra.Command {
+	var (
+		continueOnError bool
+		workers         int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-all [directory]",
+		Short: "Create blueprints from every file in a directory",
+		Long:  "Validate and create a blueprint from each JSON or YAML file in a directory, then print a summary.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreateAll(args[0], continueOnError, workers)
+		},
+	}
+
+	cmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "keep creating remaining files after a failure")
+	cmd.Flags().IntVar(&workers, "concurrency", concurrency.DefaultLimit, "maximum number of blueprints to create at once")
+
+	return cmd
+}
+
+type CreateAllResult struct {
+	File   string `json:"file" table:"FILE"`
+	Status string `json:"status" table:"STATUS"`
+	ID     string `json:"id,omitempty" table:"ID"`
+	Name   string `json:"name,omitempty" table:"NAME"`
+	Error  string `json:"error,omitempty" table:"ERROR"`
+}
+
+func runCreateAll(dir string, continueOnError bool, workers int) error {
+	if err := concurrency.ValidateLimit(workers); err != nil {
+		return err
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	files, err := findBlueprintFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		fmt.Printf("No blueprint files found in %s\n", dir)
+		return nil
+	}
+
+	existingNames, err := existingBlueprintNames(apiClient)
+	if err != nil {
+		return err
+	}
+
+	// Validate every file and settle names up front so creation can run concurrently
+	results := make([]CreateAllResult, len(files))
+	blueprints := make([]map[string]interface{}, len(files))
+	failed := 0
+
+	for i, file := range files {
+		results[i] = CreateAllResult{File: filepath.Base(file), Status: "skipped"}
+
+		blueprintData, err := loadBlueprintFile(file, nil)
+		if err != nil {
+			failed++
+			results[i].Status = "failed"
+			results[i].Error = utils.TruncateString(err.Error(), 60)
+			continue
+		}
+
+		if name, ok := blueprintData["name"].(string); ok && name != "" {
+			uniqueName := utils.UniqueName(name, existingNames)
+			if uniqueName != name {
+				progress.ShowWarning(fmt.Sprintf("Blueprint name '%s' already exists, using '%s'", name, uniqueName))
+				blueprintData["name"] = uniqueName
+			}
+			existingNames[strings.ToLower(uniqueName)] = true
+		}
+
+		blueprints[i] = blueprintData
+	}
+
+	if failed > 0 && !continueOnError {
+		if err := output.Display(results, globalConfig.Format); err != nil {
+			return err
+		}
+		return fmt.Errorf("%d blueprint files failed validation, nothing was created; use --continue-on-error to create the valid files", failed)
+	}
+
+	var aborted atomic.Bool
+
+	spinner := progress.NewSpinner(fmt.Sprintf("Creating %d blueprints...", len(files)-failed))
+	spinner.Start()
+
+	_ = concurrency.Run(len(files), workers, func(i int) error {
+		if blueprints[i] == nil || aborted.Load() {
+			return nil
+		}
+
+		header, err := apiClient.CreateBlueprintRange(blueprints[i])
+		if err != nil {
+			name, _ := blueprints[i]["name"].(string)
+			recordBlueprintChange("blueprints create-all", 0, name, err)
+			if !continueOnError {
+				aborted.Store(true)
+			}
+			results[i].Status = "failed"
+			results[i].Error = utils.TruncateString(err.Error(), 60)
+			return err
+		}
+
+		recordBlueprintChange("blueprints create-all", header.ID, header.Name, nil)
+		results[i].Status = "created"
+		results[i].ID = strconv.Itoa(header.ID)
+		results[i].Name = header.Name
+		return nil
+	})
+
+	spinner.Stop()
+
+	if err := output.Display(results, globalConfig.Format); err != nil {
+		return err
+	}
+
+	created := 0
+	failed = 0
+	for _, result := range results {
+		switch result.Status {
+		case "created":
+			created++
+		case "failed":
+			failed++
+		}
+	}
+
+	if aborted.Load() {
+		return fmt.Errorf("aborted after failure (%d created, %d failed); use --continue-on-error to keep going", created, failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d blueprints failed to create", failed, len(files))
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Created %d blueprints", created))
+	return nil
+}
+
+func findBlueprintFiles(dir string) ([]string, error) {
+	expandedDir := utils.ExpandPath(dir)
+
+	entries, err := os.ReadDir(expandedDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if utils.ValidateFileExtension(entry.Name(), blueprintFileExtensions) == nil {
+			files = append(files, filepath.Join(expandedDir, entry.Name()))
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,100 @@
+package blueprints
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+const testBlueprintYAML = `name: %s
+provider: aws
+vnc: false
+vpn: false
+vpcs:
+  - name: main
+    cidr: 10.0.0.0/16
+    subnets: []
+`
+
+func writeBlueprintFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// newCreateAllServer fakes the blueprint endpoints and counts the blueprints created.
+func newCreateAllServer(t *testing.T, created *atomic.Int32) {
+	t.Helper()
+
+	newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/blueprints/ranges":
+			testutil.RespondJSON(w, http.StatusOK, `[]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/blueprints/ranges":
+			id := created.Add(1)
+			testutil.RespondJSON(w, http.StatusOK, fmt.Sprintf(`{"id":%d,"name":"lab","provider":"aws"}`, id))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Not Found"}`)
+		}
+	})
+}
+
+func TestCreateAllValidatesBeforeCreating(t *testing.T) {
+	var created atomic.Int32
+	newCreateAllServer(t, &created)
+
+	dir := writeBlueprintFiles(t, map[string]string{
+		"a.yaml": fmt.Sprintf(testBlueprintYAML, "lab-a"),
+		"b.yaml": fmt.Sprintf(testBlueprintYAML, "lab-b") + "vpcz: []\n",
+		"c.yaml": fmt.Sprintf(testBlueprintYAML, "lab-c"),
+	})
+
+	err := runCreateAll(dir, false, 2)
+	if err == nil || !strings.Contains(err.Error(), "1 blueprint files failed validation, nothing was created") {
+		t.Fatalf("runCreateAll() error = %v, want the validation failure", err)
+	}
+	if n := created.Load(); n != 0 {
+		t.Errorf("created %d blueprints, want none when a file is invalid", n)
+	}
+}
+
+func TestCreateAllContinueOnError(t *testing.T) {
+	var created atomic.Int32
+	newCreateAllServer(t, &created)
+
+	dir := writeBlueprintFiles(t, map[string]string{
+		"a.yaml": fmt.Sprintf(testBlueprintYAML, "lab-a"),
+		"b.yaml": "name: [unclosed\n",
+		"c.yaml": fmt.Sprintf(testBlueprintYAML, "lab-c"),
+	})
+
+	err := runCreateAll(dir, true, 2)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 blueprints failed to create") {
+		t.Fatalf("runCreateAll() error = %v, want one failure", err)
+	}
+	if n := created.Load(); n != 2 {
+		t.Errorf("created %d blueprints, want the 2 valid ones", n)
+	}
+}
+
+func TestCreateAllRejectsBadConcurrency(t *testing.T) {
+	var created atomic.Int32
+	newCreateAllServer(t, &created)
+
+	if err := runCreateAll(t.TempDir(), false, 0); err == nil || !strings.Contains(err.Error(), "concurrency must be at least 1") {
+		t.Fatalf("runCreateAll() error = %v, want the concurrency error", err)
+	}
+}
@@ -2,7 +2,6 @@ package blueprints
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -12,31 +11,35 @@ import (
 
 func newDeleteCommand() *cobra.Command {
 	var force bool
+	var backupDir string
 
 	cmd := &cobra.Command{
 		Use:   "delete [blueprint-id]",
 		Short: "Delete a blueprint",
-		Long:  "Permanently delete a range blueprint.",
-		Args:  cobra.ExactArgs(1),
+		Long:  "Permanently delete a range blueprint. With --backup, the blueprint is first exported to a file that 'openlabs blueprints create' accepts.",
+		Example: `  openlabs blueprints delete 3
+  openlabs blueprints delete 3 --backup ~/openlabs-backups`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelete(args[0], force)
+			return runDelete(optionalArg(args), force, backupDir)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "skip confirmation prompt")
+	cmd.Flags().StringVar(&backupDir, "backup", "", "export the blueprint to this directory before deleting it")
 	return cmd
 }
 
-func runDelete(blueprintIDStr string, force bool) error {
+func runDelete(blueprintIDStr string, force bool, backupDir string) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
-	blueprintID, err := strconv.Atoi(blueprintIDStr)
+	blueprintID, err := resolveBlueprintID(apiClient, blueprintIDStr)
 	if err != nil {
-		return fmt.Errorf("invalid blueprint ID: %s", blueprintIDStr)
+		return err
 	}
 
 	if !force {
@@ -50,17 +53,15 @@ func runDelete(blueprintIDStr string, force bool) error {
 		}
 	}
 
-	spinner := progress.NewSpinner("Deleting blueprint...")
-	spinner.Start()
-
-	err = apiClient.DeleteBlueprintRange(blueprintID)
-	spinner.Stop()
-
-	if err != nil {
-		progress.ShowError("Failed to delete blueprint")
-		return err
+	if backupDir != "" {
+		if err := backupBlueprint(apiClient, blueprintID, backupDir); err != nil {
+			return err
+		}
 	}
 
-	progress.ShowSuccess(fmt.Sprintf("Blueprint %d deleted successfully", blueprintID))
-	return nil
+	err = progress.WithSpinnerReport("Deleting blueprint...", "Failed to delete blueprint", func() (string, error) {
+		return fmt.Sprintf("Blueprint %d deleted successfully", blueprintID), apiClient.DeleteBlueprintRange(blueprintID)
+	})
+	recordBlueprintChange("blueprints delete", blueprintID, "", err)
+	return err
 }
@@ -1,11 +1,12 @@
 package blueprints
 
 import (
+	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
@@ -13,62 +14,77 @@ import (
 func newExportCommand() *cobra.Command {
 	var outputFile string
 	var format string
+	var version int
 
 	cmd := &cobra.Command{
 		Use:   "export [blueprint-id]",
 		Short: "Export a blueprint to file",
 		Long:  "Export an existing blueprint to a JSON or YAML file.",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runExport(args[0], outputFile, format)
+			return runExport(optionalArg(args), outputFile, format, version)
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "output file path (required)")
 	cmd.Flags().StringVarP(&format, "format", "f", "json", "output format (json or yaml)")
+	cmd.Flags().IntVar(&version, "version", 0, "export this saved version instead of the current blueprint (see 'blueprints versions')")
 	_ = cmd.MarkFlagRequired("output")
 
 	return cmd
 }
 
-func runExport(blueprintIDStr, outputFile, format string) error {
+func runExport(blueprintIDStr, outputFile, format string, version int) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
-	blueprintID, err := strconv.Atoi(blueprintIDStr)
+	blueprintID, err := resolveBlueprintID(apiClient, blueprintIDStr)
 	if err != nil {
-		return fmt.Errorf("invalid blueprint ID: %s", blueprintIDStr)
+		return err
 	}
 
 	if format != "json" && format != "yaml" {
 		return fmt.Errorf("invalid format: %s (valid: json, yaml)", format)
 	}
 
-	blueprint, err := apiClient.GetBlueprintRange(blueprintID)
+	blueprint, err := getBlueprintVersion(apiClient, blueprintID, version)
 	if err != nil {
-		return fmt.Errorf("failed to get blueprint: %w", err)
+		return err
 	}
 
-	spinner := progress.NewSpinner("Exporting blueprint...")
-	spinner.Start()
+	return progress.WithSpinnerReport("Exporting blueprint...", "Failed to export blueprint", func() (string, error) {
+		return fmt.Sprintf("Blueprint exported to %s", outputFile), writeBlueprintFile(outputFile, format, blueprint)
+	})
+}
 
-	var writeErr error
-	if format == "json" {
-		writeErr = utils.WriteJSONToFile(outputFile, blueprint)
-	} else {
-		writeErr = utils.WriteYAMLToFile(outputFile, blueprint)
+func writeBlueprintFile(path, format string, blueprint *client.BlueprintRange) error {
+	if format == "yaml" {
+		return utils.WriteYAMLToFile(path, blueprint)
 	}
+	return utils.WriteJSONToFile(path, blueprint)
+}
 
-	spinner.Stop()
+// backupBlueprint exports a blueprint to a timestamped file in dir before it is deleted, so it can be
+// recreated with 'openlabs blueprints create'. A blueprint that no longer exists has nothing to back
+// up and only produces a warning.
+func backupBlueprint(apiClient *client.Client, blueprintID int, dir string) error {
+	blueprint, err := apiClient.GetBlueprintRange(blueprintID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			progress.ShowWarning(fmt.Sprintf("Blueprint %d not found; nothing to back up", blueprintID))
+			return nil
+		}
+		return fmt.Errorf("failed to back up blueprint: %w", err)
+	}
 
-	if writeErr != nil {
-		progress.ShowError("Failed to export blueprint")
-		return writeErr
+	path := utils.BackupPath(dir, fmt.Sprintf("blueprint-%d", blueprintID))
+	if err := writeBlueprintFile(path, "json", blueprint); err != nil {
+		return fmt.Errorf("failed to back up blueprint: %w", err)
 	}
 
-	progress.ShowSuccess(fmt.Sprintf("Blueprint exported to %s", outputFile))
+	progress.ShowSuccess(fmt.Sprintf("Blueprint %d backed up to %s", blueprintID, path))
 	return nil
 }
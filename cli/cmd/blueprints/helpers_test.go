@@ -0,0 +1,18 @@
+package blueprints
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+// newTestClient returns a client for a fake API served by handler and makes its config the package's.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+
+	globalConfig = testutil.FakeAPI(t, handler)
+	t.Cleanup(func() { globalConfig = nil })
+	return client.New(globalConfig)
+}
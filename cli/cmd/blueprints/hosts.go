@@ -0,0 +1,110 @@
+package blueprints
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+)
+
+// BlueprintHostRow is one host of a blueprint along with the VPC and subnet that contain it.
+type BlueprintHostRow struct {
+	VPC      string   `json:"vpc"`
+	Subnet   string   `json:"subnet"`
+	Hostname string   `json:"hostname"`
+	OS       string   `json:"os"`
+	Spec     string   `json:"spec"`
+	Size     int      `json:"size"`
+	Tags     []string `json:"tags"`
+}
+
+// BlueprintHostsTotal summarizes the hosts of a blueprint.
+type BlueprintHostsTotal struct {
+	Hosts     int `json:"hosts"`
+	TotalSize int `json:"total_size"`
+}
+
+func newHostsCommand() *cobra.Command {
+	var total bool
+
+	cmd := &cobra.Command{
+		Use:   "hosts [blueprint-id]",
+		Short: "List every host in a blueprint",
+		Long:  "Flatten the hosts across all VPCs and subnets of a blueprint into a single table.",
+		Example: `  openlabs blueprints hosts 12
+  openlabs blueprints hosts 12 --total`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHosts(optionalArg(args), total)
+		},
+	}
+
+	cmd.Flags().BoolVar(&total, "total", false, "include the host count and aggregate disk size")
+
+	return cmd
+}
+
+func runHosts(blueprintIDStr string, total bool) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	blueprintID, err := resolveBlueprintID(apiClient, blueprintIDStr)
+	if err != nil {
+		return err
+	}
+
+	blueprint, err := apiClient.GetBlueprintRange(blueprintID)
+	if err != nil {
+		return fmt.Errorf("failed to get blueprint: %w", err)
+	}
+
+	rows := []BlueprintHostRow{}
+	summary := BlueprintHostsTotal{}
+	forEachBlueprintHost(blueprint, func(vpc *client.BlueprintVPC, subnet *client.BlueprintSubnet, host *client.BlueprintHost) {
+		rows = append(rows, BlueprintHostRow{
+			VPC:      vpc.Name,
+			Subnet:   subnet.Name,
+			Hostname: host.Hostname,
+			OS:       host.OS,
+			Spec:     host.Spec,
+			Size:     host.Size,
+			Tags:     host.Tags,
+		})
+		summary.Hosts++
+		summary.TotalSize += host.Size
+	})
+
+	var result interface{} = rows
+	if total {
+		result = struct {
+			Hosts []BlueprintHostRow  `json:"hosts"`
+			Total BlueprintHostsTotal `json:"total"`
+		}{rows, summary}
+	}
+
+	if !output.IsTable(globalConfig.Format) {
+		return output.Display(result, globalConfig.Format)
+	}
+
+	if len(rows) == 0 {
+		fmt.Printf("Blueprint %d has no hosts\n", blueprintID)
+		return output.WriteAlso(result)
+	}
+
+	table, err := output.NewFormatter("table").Format(rows)
+	if err != nil {
+		return err
+	}
+	fmt.Print(table)
+
+	if total {
+		fmt.Printf("\nTotal: %d hosts, %dGB disk\n", summary.Hosts, summary.TotalSize)
+	}
+
+	return output.WriteAlso(result)
+}
@@ -5,28 +5,64 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
+type listOptions struct {
+	pageSize int
+	sortBy   string
+	all      bool
+}
+
 func newListCommand() *cobra.Command {
-	return &cobra.Command{
+	var opts listOptions
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List available blueprints",
-		Long:  "Show all available range blueprints.",
+		Long: `Show all available range blueprints.
+
+With --page-size only the first page is shown. Paging and --sort-by are passed to the server; when the
+server does not support them the CLI sorts and pages locally. Against a server that pages but does not
+sort, --sort-by only orders the page that was fetched unless --all fetches every page.`,
+		Example: `  openlabs blueprints list --sort-by name
+  openlabs blueprints list --page-size 50 --all`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList()
+			return runList(opts)
 		},
 	}
+
+	cmd.Flags().IntVar(&opts.pageSize, "page-size", 0, "number of blueprints per page")
+	cmd.Flags().StringVar(&opts.sortBy, "sort-by", "", "field to sort by, prefixed with - for descending (e.g. name, -id)")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "fetch every page when --page-size is set")
+
+	return cmd
 }
 
-func runList() error {
+func runList(opts listOptions) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
-	blueprints, err := apiClient.ListBlueprintRanges()
+	if opts.pageSize < 0 {
+		return fmt.Errorf("--page-size must be positive")
+	}
+
+	if opts.sortBy != "" {
+		if err := utils.ValidateSortField(client.BlueprintRangeHeader{}, opts.sortBy); err != nil {
+			return err
+		}
+	}
+
+	blueprints, err := apiClient.ListBlueprintRangesWithOptions(client.ListOptions{
+		PageSize: opts.pageSize,
+		Sort:     opts.sortBy,
+		All:      opts.all,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to list blueprints: %w", err)
 	}
@@ -36,5 +72,16 @@ func runList() error {
 		return nil
 	}
 
-	return output.Display(blueprints, globalConfig.OutputFormat)
+	if opts.sortBy != "" {
+		if err := utils.SortByField(blueprints, opts.sortBy); err != nil {
+			return err
+		}
+	}
+
+	// A server without paging returns everything, so trim to the requested page locally
+	if opts.pageSize > 0 && !opts.all && len(blueprints) > opts.pageSize {
+		blueprints = blueprints[:opts.pageSize]
+	}
+
+	return output.Display(blueprints, globalConfig.Format)
 }
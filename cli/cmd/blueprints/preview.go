@@ -0,0 +1,52 @@
+package blueprints
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+func newPreviewCommand() *cobra.Command {
+	var vars utils.TemplateVars
+
+	cmd := &cobra.Command{
+		Use:   "preview [file]",
+		Short: "Preview a blueprint file",
+		Long:  "Validate a local blueprint file and render its VPC, subnet, and host layout without contacting the server.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPreview(args[0], vars)
+		},
+	}
+
+	addTemplateVarFlags(cmd, &vars)
+
+	return cmd
+}
+
+func runPreview(file string, vars utils.TemplateVars) error {
+	values, err := vars.Values()
+	if err != nil {
+		return err
+	}
+
+	blueprintData, err := loadBlueprintFile(file, values)
+	if err != nil {
+		return fmt.Errorf("blueprint validation failed: %w", err)
+	}
+
+	blueprint, err := parseBlueprint(blueprintData)
+	if err != nil {
+		return fmt.Errorf("blueprint validation failed: %w", err)
+	}
+
+	if output.IsTable(globalConfig.Format) {
+		displayBlueprintTable(blueprint)
+		return output.WriteAlso(blueprint)
+	}
+
+	return output.Display(blueprint, globalConfig.Format)
+}
@@ -2,7 +2,6 @@ package blueprints
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -12,44 +11,68 @@ import (
 )
 
 func newShowCommand() *cobra.Command {
-	return &cobra.Command{
+	var raw bool
+	var version int
+
+	cmd := &cobra.Command{
 		Use:   "show [blueprint-id]",
 		Short: "Show blueprint details",
 		Long:  "Display detailed information about a specific blueprint.",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runShow(args[0])
+			return runShow(optionalArg(args), raw, version)
 		},
 	}
+
+	cmd.Flags().BoolVar(&raw, "raw", false, "print the unmodified JSON returned by the server")
+	cmd.Flags().IntVar(&version, "version", 0, "show this saved version instead of the current blueprint (see 'blueprints versions')")
+	cmd.MarkFlagsMutuallyExclusive("raw", "version")
+
+	return cmd
 }
 
-func runShow(blueprintIDStr string) error {
+func runShow(blueprintIDStr string, raw bool, version int) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
-	blueprintID, err := strconv.Atoi(blueprintIDStr)
+	blueprintID, err := resolveBlueprintID(apiClient, blueprintIDStr)
 	if err != nil {
-		return fmt.Errorf("invalid blueprint ID: %s", blueprintIDStr)
+		return err
+	}
+
+	if raw {
+		body, err := apiClient.GetBlueprintRangeRaw(blueprintID)
+		if err != nil {
+			return fmt.Errorf("failed to get blueprint: %w", err)
+		}
+		fmt.Println(string(body))
+		return nil
 	}
 
-	blueprint, err := apiClient.GetBlueprintRange(blueprintID)
+	blueprint, err := getBlueprintVersion(apiClient, blueprintID, version)
 	if err != nil {
-		return fmt.Errorf("failed to get blueprint: %w", err)
+		return err
 	}
 
-	if globalConfig.OutputFormat == "table" {
+	if output.IsTable(globalConfig.Format) {
 		displayBlueprintTable(blueprint)
-		return nil
+		return output.WriteAlso(blueprint)
 	}
 
-	return output.Display(blueprint, globalConfig.OutputFormat)
+	return output.Display(blueprint, globalConfig.Format)
 }
 
+// displayBlueprintTable renders a blueprint as a VPC → subnet → host tree. Blueprints that haven't
+// been created on the server yet have no ID and are shown without one.
 func displayBlueprintTable(blueprint *client.BlueprintRange) {
-	fmt.Printf("Blueprint #%d: %s\n", blueprint.ID, blueprint.Name)
+	if blueprint.ID != 0 {
+		fmt.Printf("Blueprint #%d: %s\n", blueprint.ID, blueprint.Name)
+	} else {
+		fmt.Printf("Blueprint: %s\n", blueprint.Name)
+	}
 	if blueprint.Description != "" {
 		fmt.Printf("Description: %s\n", blueprint.Description)
 	}
@@ -84,3 +107,17 @@ func displayBlueprintTable(blueprint *client.BlueprintRange) {
 		fmt.Println("(no VPCs defined)")
 	}
 }
+
+// forEachBlueprintHost calls fn for every host in a blueprint, in the order displayBlueprintTable
+// lists them, along with the VPC and subnet that contain it.
+func forEachBlueprintHost(blueprint *client.BlueprintRange, fn func(vpc *client.BlueprintVPC, subnet *client.BlueprintSubnet, host *client.BlueprintHost)) {
+	for i := range blueprint.VPCs {
+		vpc := &blueprint.VPCs[i]
+		for j := range vpc.Subnets {
+			subnet := &vpc.Subnets[j]
+			for k := range subnet.Hosts {
+				fn(vpc, subnet, &subnet.Hosts[k])
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package blueprints
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/concurrency"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+// BlueprintStats summarizes the user's blueprint library.
+type BlueprintStats struct {
+	Blueprints           int            `json:"blueprints"`
+	ByProvider           map[string]int `json:"by_provider"`
+	TotalHosts           int            `json:"total_hosts"`
+	AverageHosts         float64        `json:"average_hosts"`
+	WithVNC              int            `json:"with_vnc"`
+	WithVPN              int            `json:"with_vpn"`
+	UnreadableBlueprints int            `json:"unreadable_blueprints,omitempty"`
+}
+
+func newStatsCommand() *cobra.Command {
+	var workers int
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize your blueprint library",
+		Long:  "Show aggregate figures across all blueprints: how many there are per provider, their hosts, and how many enable VNC or VPN.",
+		Example: `  openlabs blueprints stats
+  openlabs blueprints stats --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStats(workers)
+		},
+	}
+
+	cmd.Flags().IntVar(&workers, "concurrency", concurrency.DefaultLimit, "maximum number of blueprints to fetch at once")
+
+	return cmd
+}
+
+func runStats(workers int) error {
+	if err := concurrency.ValidateLimit(workers); err != nil {
+		return err
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	headers, err := apiClient.ListBlueprintRanges()
+	if err != nil {
+		return fmt.Errorf("failed to list blueprints: %w", err)
+	}
+
+	stats := BlueprintStats{Blueprints: len(headers), ByProvider: map[string]int{}}
+	for _, header := range headers {
+		stats.ByProvider[header.Provider]++
+		if header.VNC {
+			stats.WithVNC++
+		}
+		if header.VPN {
+			stats.WithVPN++
+		}
+	}
+
+	hostCounts, failed := fetchHostCounts(apiClient, headers, workers)
+	for _, header := range headers {
+		if count, ok := hostCounts[header.ID]; ok {
+			stats.TotalHosts += count
+		}
+	}
+	stats.UnreadableBlueprints = failed
+
+	if readable := stats.Blueprints - failed; readable > 0 {
+		stats.AverageHosts = math.Round(float64(stats.TotalHosts)/float64(readable)*10) / 10
+	}
+
+	return output.Display(stats, globalConfig.Format)
+}
+
+// fetchHostCounts fetches each listed blueprint once, at most workers at a time, and counts its hosts.
+// Blueprints that cannot be fetched are left out of the result and reported with a warning.
+func fetchHostCounts(apiClient *client.Client, headers []client.BlueprintRangeHeader, workers int) (map[int]int, int) {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, header := range headers {
+		if !seen[header.ID] {
+			seen[header.ID] = true
+			ids = append(ids, header.ID)
+		}
+	}
+
+	counts := make([]int, len(ids))
+	fetched := make([]bool, len(ids))
+	err := concurrency.Run(len(ids), workers, func(i int) error {
+		blueprint, err := apiClient.GetBlueprintRange(ids[i])
+		if err != nil {
+			return fmt.Errorf("blueprint %d: %w", ids[i], err)
+		}
+		forEachBlueprintHost(blueprint, func(*client.BlueprintVPC, *client.BlueprintSubnet, *client.BlueprintHost) {
+			counts[i]++
+		})
+		fetched[i] = true
+		return nil
+	})
+
+	hostCounts := make(map[int]int, len(ids))
+	for i, id := range ids {
+		if fetched[i] {
+			hostCounts[id] = counts[i]
+		}
+	}
+
+	failed := len(ids) - len(hostCounts)
+	if err != nil {
+		progress.ShowWarning(fmt.Sprintf("%d blueprints could not be read and are left out of the host figures:\n%v", failed, err))
+	}
+
+	return hostCounts, failed
+}
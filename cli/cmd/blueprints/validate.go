@@ -1,41 +1,80 @@
 package blueprints
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
 func newValidateCommand() *cobra.Command {
-	return &cobra.Command{
+	var vars utils.TemplateVars
+	var server bool
+
+	cmd := &cobra.Command{
 		Use:   "validate [file]",
 		Short: "Validate a blueprint file",
-		Long:  "Validate a blueprint JSON or YAML file without creating it.",
-		Args:  cobra.ExactArgs(1),
+		Long:  "Validate a blueprint JSON or YAML file without creating it. By default only the file's structure is checked locally; --server also submits it to the API, which applies every rule a real create would.",
+		Example: `  openlabs blueprints validate lab.yaml
+  openlabs blueprints validate lab.yaml --server`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runValidate(args[0])
+			return runValidate(args[0], vars, server)
 		},
 	}
+
+	addTemplateVarFlags(cmd, &vars)
+	cmd.Flags().BoolVar(&server, "server", false, "also validate the blueprint on the server without creating it")
+
+	return cmd
 }
 
 // Eventually, we want real validation here. Preferably local, but replicating the pydantic logic may be annoying.
-func runValidate(file string) error {
-	if err := utils.ValidateFileExists(file); err != nil {
+func runValidate(file string, vars utils.TemplateVars, server bool) error {
+	values, err := vars.Values()
+	if err != nil {
 		return err
 	}
 
-	if err := utils.ValidateFileExtension(file, []string{".json", ".yaml", ".yml"}); err != nil {
-		return err
+	blueprintData, err := loadBlueprintFile(file, values)
+	if err != nil {
+		return fmt.Errorf("blueprint validation failed: %w", err)
 	}
 
-	var blueprintData interface{}
-	if err := utils.ReadFileAsStructured(file, &blueprintData); err != nil {
-		return fmt.Errorf("blueprint validation failed: %w", err)
+	if server {
+		return validateOnServer(blueprintData)
 	}
 
 	progress.ShowSuccess("Blueprint file is valid")
 	return nil
 }
+
+// validateOnServer submits a locally valid blueprint for server-side validation, falling back to
+// the local result when the server has no validation endpoint.
+func validateOnServer(blueprintData interface{}) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	err := progress.WithSpinner("Validating blueprint on the server...", func() error {
+		return apiClient.ValidateBlueprintRange(blueprintData)
+	})
+	if errors.Is(err, client.ErrNotSupported) {
+		progress.ShowWarning("Server-side validation is not supported by this server; only the local checks were run")
+		progress.ShowSuccess("Blueprint file is valid")
+		return nil
+	}
+	if err != nil {
+		progress.ShowError("Blueprint was rejected by the server")
+		return fmt.Errorf("blueprint validation failed: %w", err)
+	}
+
+	progress.ShowSuccess("Blueprint is valid and can be created")
+	return nil
+}
@@ -0,0 +1,54 @@
+package blueprints
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+)
+
+func newVersionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "versions [blueprint-id]",
+		Short: "List saved versions of a blueprint",
+		Long:  "List the versions the server has kept of a blueprint, oldest first. Any of them can be viewed with 'blueprints show --version' or saved with 'blueprints export --version', for example to recreate an earlier blueprint. Requires a server that keeps blueprint versions.",
+		Example: `  # List the versions of blueprint 5
+  openlabs blueprints versions 5
+
+  # Look at version 2 and export it
+  openlabs blueprints show 5 --version 2
+  openlabs blueprints export 5 --version 2 -o web-lab-v2.yaml -f yaml`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVersions(optionalArg(args))
+		},
+	}
+
+	return cmd
+}
+
+func runVersions(blueprintIDStr string) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	blueprintID, err := resolveBlueprintID(apiClient, blueprintIDStr)
+	if err != nil {
+		return err
+	}
+
+	versions, err := apiClient.ListBlueprintVersions(blueprintID)
+	if err != nil {
+		return versionsError(apiClient, blueprintID, err)
+	}
+
+	if len(versions) == 0 && output.IsTable(globalConfig.Format) {
+		fmt.Printf("No saved versions of blueprint %d.\n", blueprintID)
+		return nil
+	}
+
+	return output.Display(versions, globalConfig.Format)
+}
@@ -0,0 +1,119 @@
+package blueprints
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+// versionedAPI fakes a server that keeps versions 1 and 2 of blueprint 5 and none of blueprint 6,
+// and has no blueprint 99.
+func versionedAPI(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/v1/blueprints/ranges/99") {
+		testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Blueprint range with ID: 99 not found!"}`)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/v1/blueprints/ranges/5":
+		testutil.RespondJSON(w, http.StatusOK, `{"id":5,"name":"web-lab-v3","provider":"aws","vpcs":[]}`)
+	case "/api/v1/blueprints/ranges/5/versions":
+		testutil.RespondJSON(w, http.StatusOK, `[{"version":1,"created_at":"2026-01-02T03:04:05Z","name":"web-lab"},{"version":2,"created_at":"2026-02-03T04:05:06Z","name":"web-lab-v2"}]`)
+	case "/api/v1/blueprints/ranges/6/versions":
+		testutil.RespondJSON(w, http.StatusOK, `[]`)
+	case "/api/v1/blueprints/ranges/5/versions/2":
+		testutil.RespondJSON(w, http.StatusOK, `{"id":5,"name":"web-lab-v2","provider":"aws","vpcs":[]}`)
+	default:
+		testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Not Found"}`)
+	}
+}
+
+// unversionedAPI fakes a server that has blueprint 5 but keeps no versions of blueprints.
+func unversionedAPI(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api/v1/blueprints/ranges/5":
+		testutil.RespondJSON(w, http.StatusOK, `{"id":5,"name":"web-lab","provider":"aws","vpcs":[]}`)
+	case "/api/v1/blueprints/ranges/99":
+		testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Blueprint range with ID: 99 not found!"}`)
+	default:
+		testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Not Found"}`)
+	}
+}
+
+func TestGetBlueprintVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		blueprintID int
+		version     int
+		wantName    string
+		wantErr     error
+		wantErrText string
+	}{
+		{name: "current blueprint", handler: versionedAPI, blueprintID: 5, version: 0, wantName: "web-lab-v3"},
+		{name: "saved version", handler: versionedAPI, blueprintID: 5, version: 2, wantName: "web-lab-v2"},
+		{name: "missing version", handler: versionedAPI, blueprintID: 5, version: 9, wantErrText: "blueprint 5 has no version 9 (latest is 2)"},
+		{name: "no saved versions", handler: versionedAPI, blueprintID: 6, version: 1, wantErrText: "blueprint 6 has no saved versions"},
+		{name: "server without versioning", handler: unversionedAPI, blueprintID: 5, version: 1, wantErr: errVersioningNotSupported},
+		{name: "missing blueprint", handler: versionedAPI, blueprintID: 99, version: 1, wantErrText: "blueprint 99 not found"},
+		{name: "missing blueprint without versioning", handler: unversionedAPI, blueprintID: 99, version: 1, wantErrText: "blueprint 99 not found"},
+		{name: "current blueprint without versioning", handler: unversionedAPI, blueprintID: 5, version: 0, wantName: "web-lab"},
+		{name: "negative version", handler: versionedAPI, blueprintID: 5, version: -1, wantErrText: "--version must be a positive number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, tt.handler)
+
+			blueprint, err := getBlueprintVersion(apiClient, tt.blueprintID, tt.version)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("getBlueprintVersion() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantErrText != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrText) {
+					t.Fatalf("getBlueprintVersion() error = %v, want %q", err, tt.wantErrText)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("getBlueprintVersion() error = %v", err)
+				}
+				if blueprint.Name != tt.wantName {
+					t.Errorf("getBlueprintVersion() = %s, want %s", blueprint.Name, tt.wantName)
+				}
+			}
+		})
+	}
+}
+
+func TestRunVersionsErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		blueprintID string
+		wantErr     error
+		wantErrText string
+	}{
+		{name: "server without versioning", handler: unversionedAPI, blueprintID: "5", wantErr: errVersioningNotSupported},
+		{name: "missing blueprint", handler: versionedAPI, blueprintID: "99", wantErrText: "blueprint 99 not found"},
+		{name: "missing blueprint without versioning", handler: unversionedAPI, blueprintID: "99", wantErrText: "blueprint 99 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newTestClient(t, tt.handler)
+
+			err := runVersions(tt.blueprintID)
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("runVersions() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErrText != "" && (err == nil || err.Error() != tt.wantErrText) {
+				t.Errorf("runVersions() error = %v, want %q", err, tt.wantErrText)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"github.com/spf13/cobra"
+
+	internalCache "github.com/OpenLabsHQ/OpenLabs/cli/internal/cache"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+)
+
+var globalConfig *config.Config
+
+func SetGlobalConfig(cfg *config.Config) {
+	globalConfig = cfg
+}
+
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage cached API responses",
+		Long:  "Inspect or clear the blueprint and region responses the CLI caches under ~/.openlabs/cache. Pass --no-cache to any command to bypass the cache once.",
+	}
+
+	cmd.AddCommand(newClearCommand())
+	cmd.AddCommand(newStatusCommand())
+
+	return cmd
+}
+
+// openCache opens the on-disk response cache.
+func openCache() (*internalCache.Cache, error) {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return internalCache.Open(dir), nil
+}
@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+func newClearCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "clear",
+		Short:   "Delete all cached API responses",
+		Long:    "Delete every cached response, so the next commands fetch fresh data from the API.",
+		Example: `  openlabs cache clear`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClear()
+		},
+	}
+}
+
+func runClear() error {
+	responseCache, err := openCache()
+	if err != nil {
+		return err
+	}
+
+	removed := responseCache.Stats().Entries
+	if err := responseCache.Clear(); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Removed %d cached responses", removed))
+	return nil
+}
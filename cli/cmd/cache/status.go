@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+)
+
+// CacheStatus describes the on-disk response cache.
+type CacheStatus struct {
+	Directory string `json:"directory"`
+	Entries   int    `json:"entries"`
+	Expired   int    `json:"expired"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+func newStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show how much is cached",
+		Long:  "Show where cached responses are kept, how many there are, how many have expired, and their size on disk.",
+		Example: `  openlabs cache status
+  openlabs cache status --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatus()
+		},
+	}
+}
+
+func runStatus() error {
+	responseCache, err := openCache()
+	if err != nil {
+		return err
+	}
+
+	stats := responseCache.Stats()
+	status := CacheStatus{
+		Directory: responseCache.Dir(),
+		Entries:   stats.Entries,
+		Expired:   stats.Expired,
+		SizeBytes: stats.Bytes,
+	}
+
+	if !output.IsTable(globalConfig.Format) {
+		return output.Display(status, globalConfig.Format)
+	}
+
+	fmt.Printf("Directory: %s\n", status.Directory)
+	fmt.Printf("Entries:   %d (%d expired)\n", status.Entries, status.Expired)
+	fmt.Printf("Size:      %s\n", formatSize(status.SizeBytes))
+	return output.WriteAlso(status)
+}
+
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
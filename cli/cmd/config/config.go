@@ -13,6 +13,9 @@ func NewConfigCommand() *cobra.Command {
 
 	cmd.AddCommand(newShowCommand())
 	cmd.AddCommand(newSetCommand())
+	cmd.AddCommand(newExportCommand())
+	cmd.AddCommand(newImportCommand())
+	cmd.AddCommand(newMigrateCommand())
 
 	return cmd
 }
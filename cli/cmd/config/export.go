@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	internalConfig "github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+func newExportCommand() *cobra.Command {
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export shareable settings",
+		Long:  "Write the API URL, output format, time format, and timeout to a JSON file that teammates can load with 'openlabs config import'. Credentials are never exported.",
+		Example: `  openlabs config export --output team.json
+  openlabs config export > team.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(outputFile)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "file to write (default: stdout)")
+
+	return cmd
+}
+
+func runExport(outputFile string) error {
+	config, err := internalConfig.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	settings := config.SharedSettings()
+
+	if outputFile == "" {
+		data, err := json.MarshalIndent(settings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal settings: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if err := utils.WriteJSONToFile(outputFile, settings); err != nil {
+		return err
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Settings exported to %s", outputFile))
+	return nil
+}
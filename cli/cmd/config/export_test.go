@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	internalConfig "github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+)
+
+func TestExportOmitsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(internalConfig.HomeEnv, dir)
+
+	cfg := internalConfig.DefaultConfig()
+	cfg.AuthToken = "secret-auth-token"
+	cfg.EncryptionKey = "secret-encryption-key"
+	cfg.CSRFToken = "secret-csrf-token"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	exportPath := filepath.Join(dir, "team.json")
+	if err := runExport(exportPath); err != nil {
+		t.Fatalf("runExport() error = %v", err)
+	}
+
+	data, err := os.ReadFile(exportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-", "auth_token", "encryption_key", "csrf_token", "ssh_key_path"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("export contains %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), cfg.APIURL) {
+		t.Errorf("export lacks the API URL:\n%s", data)
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	internalConfig "github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+func newImportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "import [file]",
+		Short:   "Import shared settings",
+		Long:    "Merge settings from a file written by 'openlabs config export' into the current configuration. Only settings present in the file change; credentials in the file are ignored.",
+		Example: `  openlabs config import team.json`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(args[0])
+		},
+	}
+}
+
+func runImport(file string) error {
+	data, err := os.ReadFile(utils.ExpandPath(file))
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", file, err)
+	}
+
+	settings, ignored, err := internalConfig.ParseSharedSettings(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+
+	if settings.OutputFormat != "" {
+		if err := utils.ValidateOutputFormat(settings.OutputFormat); err != nil {
+			return err
+		}
+	}
+
+	if settings.TimeFormat != "" {
+		if err := output.ValidateTimeFormat(settings.TimeFormat); err != nil {
+			return err
+		}
+	}
+
+	if len(ignored) > 0 {
+		progress.ShowWarning(fmt.Sprintf("Ignored credential fields in %s: %s", file, strings.Join(ignored, ", ")))
+	}
+
+	config, err := internalConfig.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	changed := config.ApplySharedSettings(settings)
+	if len(changed) == 0 {
+		progress.ShowInfo("Configuration already matches the imported settings")
+		return nil
+	}
+
+	if err := config.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Imported settings: %s", strings.Join(changed, ", ")))
+	return nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	internalConfig "github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+func newMigrateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade the configuration file format",
+		Long:  "Upgrade the configuration file to the current schema version, keeping a backup of the original. This also happens automatically whenever an older config file is loaded.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrate()
+		},
+	}
+}
+
+func runMigrate() error {
+	configPath, err := internalConfig.GetConfigPath()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		fmt.Printf("No configuration file found at %s\n", configPath)
+		return nil
+	}
+
+	result, err := internalConfig.Migrate(configPath)
+	if err != nil {
+		return err
+	}
+
+	if !result.Migrated() {
+		fmt.Printf("Configuration is already at schema version %d\n", result.ToVersion)
+		return nil
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Migrated configuration from schema version %d to %d", result.FromVersion, result.ToVersion))
+	fmt.Printf("Backup of the original saved to %s\n", result.BackupPath)
+	return nil
+}
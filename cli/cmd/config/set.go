@@ -2,10 +2,14 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	internalConfig "github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
@@ -14,22 +18,32 @@ func newSetCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set [key] [value]",
 		Short: "Set configuration value",
-		Long:  "Set a configuration value. Available keys: api-url, format",
-		Args:  cobra.ExactArgs(2),
+		Long:  "Set a configuration value. Available keys: api-url, web-url, format, format.<command>, time-format, telemetry, telemetry-url, deploy-estimate-base, deploy-estimate-per-host, audit-log. A format.<command> key, such as format.range.jobs, sets the output format of that command and its subcommands when --format is not given; an empty value removes it. telemetry turns anonymous usage data on or off (true or false); telemetry-url sends it somewhere other than the API server, and an empty value restores that default. deploy-estimate-base and deploy-estimate-per-host tune the rough deploy time shown when the server gives no estimate (a fixed part plus a part per host, as durations); an empty value restores the default. audit-log turns the local record of deploys, destroys, and blueprint changes on or off (true or false); view it with 'openlabs audit'.",
+		Example: `  openlabs config set format table
+  openlabs config set format.range.jobs json
+  openlabs config set format.range.jobs ""
+  openlabs config set telemetry false
+  openlabs config set deploy-estimate-per-host 90s
+  openlabs config set audit-log true`,
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSet(args[0], args[1])
+			return runSet(cmd.Root(), args[0], args[1])
 		},
 	}
 
 	return cmd
 }
 
-func runSet(key, value string) error {
+func runSet(root *cobra.Command, key, value string) error {
 	config, err := internalConfig.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	if commandPath, ok := strings.CutPrefix(key, "format."); ok {
+		return setFormatOverride(root, config, commandPath, value)
+	}
+
 	switch key {
 	case "api-url":
 		if err := config.SetAPIURL(value); err != nil {
@@ -37,6 +51,22 @@ func runSet(key, value string) error {
 		}
 		progress.ShowSuccess(fmt.Sprintf("API URL set to: %s", value))
 
+	case "web-url":
+		// An empty value goes back to deriving the web URL from the API URL
+		if value != "" {
+			if err := utils.ValidateURL(value); err != nil {
+				return err
+			}
+		}
+		if err := config.SetWebURL(value); err != nil {
+			return err
+		}
+		if value == "" {
+			progress.ShowSuccess("Web URL cleared; it will be derived from the API URL")
+		} else {
+			progress.ShowSuccess(fmt.Sprintf("Web URL set to: %s", value))
+		}
+
 	case "format":
 		if err := utils.ValidateOutputFormat(value); err != nil {
 			return err
@@ -46,9 +76,137 @@ func runSet(key, value string) error {
 		}
 		progress.ShowSuccess(fmt.Sprintf("Output format set to: %s", value))
 
+	case "time-format":
+		if err := output.ValidateTimeFormat(value); err != nil {
+			return err
+		}
+		if err := config.SetTimeFormat(value); err != nil {
+			return err
+		}
+		progress.ShowSuccess(fmt.Sprintf("Time format set to: %s", value))
+
+	case "telemetry":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid telemetry value: %s (valid: true, false)", value)
+		}
+		if err := config.SetTelemetry(enabled); err != nil {
+			return err
+		}
+		if enabled {
+			progress.ShowSuccess(fmt.Sprintf("Telemetry enabled; anonymous usage data is sent to %s", config.TelemetryEndpoint()))
+		} else {
+			progress.ShowSuccess("Telemetry disabled; no usage data is sent")
+		}
+
+	case "telemetry-url":
+		if value != "" {
+			if err := utils.ValidateURL(value); err != nil {
+				return err
+			}
+		}
+		if err := config.SetTelemetryURL(value); err != nil {
+			return err
+		}
+		progress.ShowSuccess(fmt.Sprintf("Telemetry endpoint set to: %s", config.TelemetryEndpoint()))
+
+	case "deploy-estimate-base", "deploy-estimate-per-host":
+		var d time.Duration
+		if value != "" {
+			d, err = utils.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid duration for %s: %s (e.g. 5m, 90s)", key, value)
+			}
+		}
+
+		base, perHost := config.DeployEstimateBase, config.DeployEstimatePerHost
+		if key == "deploy-estimate-base" {
+			base = d
+		} else {
+			perHost = d
+		}
+		if err := config.SetDeployEstimate(base, perHost); err != nil {
+			return err
+		}
+		progress.ShowSuccess(fmt.Sprintf("Deploy estimate set to %s plus %s per host", config.DeployEstimate(0), config.DeployEstimate(1)-config.DeployEstimate(0)))
+
+	case "audit-log":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid audit-log value: %s (valid: true, false)", value)
+		}
+		if err := config.SetAuditLog(enabled); err != nil {
+			return err
+		}
+		if enabled {
+			progress.ShowSuccess("Audit log enabled; view it with 'openlabs audit'")
+		} else {
+			progress.ShowSuccess("Audit log disabled; existing entries are kept")
+		}
+
 	default:
-		return fmt.Errorf("unknown configuration key: %s (valid: api-url, format)", key)
+		return fmt.Errorf("unknown configuration key: %s (valid: api-url, web-url, format, format.<command>, time-format, telemetry, telemetry-url, deploy-estimate-base, deploy-estimate-per-host, audit-log)", key)
 	}
 
 	return nil
 }
+
+func setFormatOverride(root *cobra.Command, config *internalConfig.Config, commandPath, format string) error {
+	commandPath = strings.ToLower(strings.TrimSpace(commandPath))
+
+	// An override left behind for a command that no longer exists can still be removed
+	_, saved := config.FormatOverrides[commandPath]
+	if format != "" || !saved {
+		resolved, err := resolveCommandPath(root, commandPath)
+		if err != nil {
+			return err
+		}
+		commandPath = resolved
+	}
+
+	if format != "" {
+		if err := utils.ValidateOutputFormat(format); err != nil {
+			return err
+		}
+	}
+
+	if err := config.SetFormatOverride(commandPath, format); err != nil {
+		return err
+	}
+
+	if format == "" {
+		progress.ShowSuccess(fmt.Sprintf("Removed output format override for '%s'", strings.ReplaceAll(commandPath, ".", " ")))
+	} else {
+		progress.ShowSuccess(fmt.Sprintf("Output format for '%s' set to: %s", strings.ReplaceAll(commandPath, ".", " "), format))
+	}
+	return nil
+}
+
+// resolveCommandPath checks a dotted command path such as "range.jobs" against the command tree and
+// returns it with any aliases replaced by command names, the form format overrides are matched on.
+func resolveCommandPath(root *cobra.Command, commandPath string) (string, error) {
+	var names []string
+	cmd := root
+	for _, name := range strings.Split(commandPath, ".") {
+		if name == "" {
+			return "", fmt.Errorf("invalid command path %q (use dots between command names, e.g. format.range.jobs)", commandPath)
+		}
+
+		var next *cobra.Command
+		var children []string
+		for _, child := range cmd.Commands() {
+			if child.Name() == name || child.HasAlias(name) {
+				next = child
+				break
+			}
+			children = append(children, child.Name())
+		}
+		if next == nil {
+			return "", fmt.Errorf("unknown command '%s' in format.%s%s", strings.Join(append(names, name), " "), commandPath, utils.DidYouMean(name, children))
+		}
+
+		cmd = next
+		names = append(names, cmd.Name())
+	}
+	return strings.Join(names, "."), nil
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	internalConfig "github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+)
+
+// newCommandTree returns a root with "range jobs show" and "blueprints", where "blueprints" can also
+// be run as "bp".
+func newCommandTree() *cobra.Command {
+	root := &cobra.Command{Use: "openlabs"}
+	rangeCmd := &cobra.Command{Use: "range"}
+	jobsCmd := &cobra.Command{Use: "jobs"}
+	jobsCmd.AddCommand(&cobra.Command{Use: "show <job-id>"})
+	rangeCmd.AddCommand(jobsCmd)
+	root.AddCommand(rangeCmd, &cobra.Command{Use: "blueprints", Aliases: []string{"bp"}})
+	return root
+}
+
+func TestSetFormatOverride(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    map[string]string
+		wantErr string
+	}{
+		{name: "command", key: "format.range", value: "json", want: map[string]string{"range": "json"}},
+		{name: "subcommand", key: "format.range.jobs.show", value: "yaml", want: map[string]string{"range.jobs.show": "yaml"}},
+		{name: "alias", key: "format.bp", value: "json", want: map[string]string{"blueprints": "json"}},
+		{name: "mixed case", key: "format.Range.Jobs", value: "json", want: map[string]string{"range.jobs": "json"}},
+		{name: "unknown command", key: "format.ranges", value: "json", wantErr: "unknown command 'ranges' in format.ranges (did you mean 'range'?)"},
+		{name: "unknown subcommand", key: "format.range.job", value: "json", wantErr: "unknown command 'range job'"},
+		{name: "empty name", key: "format.range..jobs", value: "json", wantErr: "invalid command path"},
+		{name: "invalid format", key: "format.range", value: "csv", wantErr: "invalid output format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(internalConfig.HomeEnv, t.TempDir())
+
+			err := runSet(newCommandTree(), tt.key, tt.value)
+
+			saved, loadErr := internalConfig.Load()
+			if loadErr != nil {
+				t.Fatal(loadErr)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("runSet(%s) error = %v, want %q", tt.key, err, tt.wantErr)
+				}
+				if len(saved.FormatOverrides) != 0 {
+					t.Errorf("rejected override saved: %v", saved.FormatOverrides)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runSet(%s) error = %v", tt.key, err)
+			}
+			if len(saved.FormatOverrides) != len(tt.want) {
+				t.Fatalf("saved overrides = %v, want %v", saved.FormatOverrides, tt.want)
+			}
+			for path, format := range tt.want {
+				if saved.FormatOverrides[path] != format {
+					t.Errorf("saved overrides = %v, want %v", saved.FormatOverrides, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveStaleFormatOverride(t *testing.T) {
+	t.Setenv(internalConfig.HomeEnv, t.TempDir())
+
+	cfg := internalConfig.DefaultConfig()
+	cfg.FormatOverrides = map[string]string{"ranges": "json"}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runSet(newCommandTree(), "format.ranges", ""); err != nil {
+		t.Fatalf("removing a stale override: error = %v", err)
+	}
+	saved, err := internalConfig.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.FormatOverrides) != 0 {
+		t.Errorf("saved overrides = %v, want the stale one removed", saved.FormatOverrides)
+	}
+
+	if err := runSet(newCommandTree(), "format.ranges", ""); err == nil {
+		t.Error("removing an unknown override that was never saved: error = nil")
+	}
+}
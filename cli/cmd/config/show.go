@@ -27,12 +27,18 @@ func runShow() error {
 	}
 
 	displayConfig := map[string]interface{}{
-		"api_url":       config.APIURL,
-		"output_format": config.OutputFormat,
-		"timeout":       config.Timeout.String(),
-		"ssh_key_path":  config.SSHKeyPath,
-		"debug":         config.Debug,
-		"authenticated": config.AuthToken != "",
+		"api_url":          config.APIURL,
+		"web_url":          config.WebURL(),
+		"output_format":    config.OutputFormat,
+		"timeout":          config.Timeout.String(),
+		"ssh_key_path":     config.SSHKeyPath,
+		"time_format":      config.TimeFormat,
+		"format_overrides": config.FormatOverrides,
+		"debug":            config.Debug,
+		"authenticated":    config.Token() != "",
+		"telemetry":        config.TelemetryOn(),
+		"audit_log":        config.AuditLog,
+		"deploy_estimate":  fmt.Sprintf("%s + %s per host", config.DeployEstimate(0), config.DeployEstimate(1)-config.DeployEstimate(0)),
 	}
 
 	return output.Display(displayConfig, config.OutputFormat)
@@ -0,0 +1,58 @@
+package ranges
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+// addKeyToAgent loads a range private key into the ssh-agent listening on SSH_AUTH_SOCK. A zero
+// lifetime leaves the key loaded until the agent exits or the key is removed.
+func addKeyToAgent(rangeID int, key string, lifetime time.Duration) error {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return fmt.Errorf("no ssh-agent available (SSH_AUTH_SOCK is not set); start one with 'eval $(ssh-agent)'")
+	}
+
+	rawKey, err := parseRangeKey(key)
+	if err != nil {
+		return err
+	}
+
+	signer, err := ssh.NewSignerFromKey(rawKey)
+	if err != nil {
+		return fmt.Errorf("failed to read range key: %w", err)
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return fmt.Errorf("failed to connect to ssh-agent at %s: %w", socket, err)
+	}
+	defer conn.Close()
+
+	addedKey := agent.AddedKey{
+		PrivateKey: rawKey,
+		Comment:    fmt.Sprintf("openlabs range %d", rangeID),
+	}
+	if lifetime > 0 {
+		addedKey.LifetimeSecs = uint32(lifetime.Round(time.Second).Seconds())
+	}
+
+	if err := agent.NewClient(conn).Add(addedKey); err != nil {
+		return fmt.Errorf("failed to add key to ssh-agent: %w", err)
+	}
+
+	message := fmt.Sprintf("Added range %d key to ssh-agent (%s)", rangeID, ssh.FingerprintSHA256(signer.PublicKey()))
+	if lifetime > 0 {
+		message += fmt.Sprintf(", expires in %v", lifetime)
+	}
+	progress.ShowSuccess(message)
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+func newJobsCancelCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "cancel [job-id]",
+		Short: "Cancel an in-progress job",
+		Long:  "Abort a queued or running range job. Resources created before cancellation may remain.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobsCancel(args[0], force)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "skip confirmation prompt")
+
+	return cmd
+}
+
+func runJobsCancel(jobID string, force bool) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	job, err := apiClient.GetJob(jobID)
+	if err != nil {
+		return err
+	}
+
+	if job.Status == "complete" || job.Status == "failed" {
+		progress.ShowInfo(fmt.Sprintf("Job %s has already finished (status: %s); nothing to cancel", jobID, job.Status))
+		return nil
+	}
+
+	if !force {
+		confirmed, err := utils.PromptConfirm(fmt.Sprintf("Are you sure you want to cancel job %s (%s)?", jobID, job.Status))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			progress.ShowInfo("Cancel aborted")
+			return nil
+		}
+	}
+
+	cancelled, err := apiClient.CancelJob(jobID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return fmt.Errorf("job cancellation is not supported by this server")
+		}
+		return err
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Cancellation requested for job %s (status: %s)", jobID, cancelled.Status))
+	progress.ShowWarning("Cloud resources created before cancellation may remain")
+	progress.ShowInfo("Use 'openlabs range destroy' to clean up any partially deployed range")
+
+	return nil
+}
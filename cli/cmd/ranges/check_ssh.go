@@ -0,0 +1,210 @@
+package ranges
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/concurrency"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+// jumpboxUsers maps providers to the login user of the jumpbox image they deploy.
+var jumpboxUsers = map[string]string{
+	"aws":   "ubuntu",
+	"azure": "azureuser",
+}
+
+type checkSSHOptions struct {
+	user    string
+	timeout time.Duration
+	workers int
+}
+
+type SSHCheckResult struct {
+	Host   string `json:"host"`
+	IP     string `json:"ip"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func newCheckSSHCommand() *cobra.Command {
+	var opts checkSSHOptions
+
+	cmd := &cobra.Command{
+		Use:   "check-ssh [range-id]",
+		Short: "Check SSH reachability of range hosts",
+		Long:  "Log in to the range jumpbox with the range key and try to open a connection to port 22 on every host through it.",
+		Example: `  # Check every host in range 12
+  openlabs range check-ssh 12
+
+  # Allow slower hosts more time
+  openlabs range check-ssh 12 --timeout 30s`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rangeID string
+			if len(args) > 0 {
+				rangeID = args[0]
+			}
+			return runCheckSSH(rangeID, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.user, "user", "", "jumpbox login user (default depends on the provider)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 10*time.Second, "timeout for each connection attempt")
+	cmd.Flags().IntVar(&opts.workers, "concurrency", concurrency.DefaultLimit, "maximum number of hosts to check at once")
+
+	return cmd
+}
+
+func runCheckSSH(rangeIDStr string, opts checkSSHOptions) error {
+	if err := concurrency.ValidateLimit(opts.workers); err != nil {
+		return err
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeID(apiClient, rangeIDStr)
+	if err != nil {
+		return err
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	if !strings.EqualFold(rangeData.State, "on") || rangeData.JumpboxPublicIP == "" {
+		progress.ShowWarning(fmt.Sprintf("Range %d is not ready yet (state: %s); try again once it is on", rangeID, rangeData.State))
+		return nil
+	}
+
+	keyResponse, err := apiClient.GetRangeKey(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey([]byte(keyResponse.RangePrivateKey))
+	if err != nil {
+		return fmt.Errorf("failed to parse range key: %w", err)
+	}
+
+	user := opts.user
+	if user == "" {
+		user = jumpboxUsers[strings.ToLower(rangeData.Provider)]
+	}
+	if user == "" {
+		return fmt.Errorf("no default jumpbox user for provider %s; use --user", rangeData.Provider)
+	}
+
+	var results []SSHCheckResult
+	err = progress.WithSpinner(fmt.Sprintf("Connecting to jumpbox %s...", rangeData.JumpboxPublicIP), func() error {
+		var err error
+		results, err = checkRangeSSH(rangeData, user, signer, opts)
+		return err
+	})
+
+	if err := output.Display(results, globalConfig.Format); err != nil {
+		return err
+	}
+
+	return err
+}
+
+// checkRangeSSH connects to the jumpbox and then dials port 22 on every host through it. The
+// returned error summarizes any unreachable hosts.
+func checkRangeSSH(rangeData *client.DeployedRange, user string, signer ssh.Signer, opts checkSSHOptions) ([]SSHCheckResult, error) {
+	jumpboxAddr := net.JoinHostPort(rangeData.JumpboxPublicIP, "22")
+	jumpbox := SSHCheckResult{Host: "jumpbox", IP: rangeData.JumpboxPublicIP, Status: "reachable"}
+
+	var hosts []client.DeployedHost
+	for _, vpc := range rangeData.VPCs {
+		for _, subnet := range vpc.Subnets {
+			hosts = append(hosts, subnet.Hosts...)
+		}
+	}
+
+	results := make([]SSHCheckResult, len(hosts))
+	for i, host := range hosts {
+		results[i] = SSHCheckResult{Host: host.Hostname, IP: host.IPAddress}
+	}
+
+	sshClient, err := ssh.Dial("tcp", jumpboxAddr, &ssh.ClientConfig{
+		User: user,
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		// Jumpboxes are created per deployment, so there is no known host key to check against
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         opts.timeout,
+	})
+	if err != nil {
+		jumpbox.Status = "unreachable"
+		jumpbox.Detail = err.Error()
+		for i := range results {
+			results[i].Status = "unknown"
+			results[i].Detail = "jumpbox unreachable"
+		}
+		return append([]SSHCheckResult{jumpbox}, results...), fmt.Errorf("failed to connect to jumpbox %s: %w", jumpboxAddr, err)
+	}
+	defer sshClient.Close()
+
+	unreachable := 0
+	_ = concurrency.Run(len(hosts), opts.workers, func(i int) error {
+		if hosts[i].IPAddress == "" {
+			results[i].Status = "unknown"
+			results[i].Detail = "no IP address assigned yet"
+			return nil
+		}
+
+		if err := dialThroughJumpbox(sshClient, net.JoinHostPort(hosts[i].IPAddress, "22"), opts.timeout); err != nil {
+			results[i].Status = "unreachable"
+			results[i].Detail = err.Error()
+			return err
+		}
+
+		results[i].Status = "reachable"
+		return nil
+	})
+
+	for _, result := range results {
+		if result.Status == "unreachable" {
+			unreachable++
+		}
+	}
+
+	results = append([]SSHCheckResult{jumpbox}, results...)
+	if unreachable > 0 {
+		return results, fmt.Errorf("%d of %d hosts are unreachable over SSH", unreachable, len(hosts))
+	}
+
+	return results, nil
+}
+
+// dialThroughJumpbox opens and immediately closes a TCP connection to addr through the jumpbox.
+func dialThroughJumpbox(sshClient *ssh.Client, addr string, timeout time.Duration) error {
+	done := make(chan error, 1)
+
+	go func() {
+		conn, err := sshClient.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+}
@@ -0,0 +1,158 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+type connectOptions struct {
+	vncOnly bool
+	vpnOnly bool
+	open    bool
+	reveal  bool
+}
+
+// RangeConnectInfo is what 'range connect' reports: the VNC console details and the path of the saved
+// VPN config, for whichever of the two the range was deployed with.
+type RangeConnectInfo struct {
+	RangeID       int             `json:"range_id"`
+	VNC           *client.VNCInfo `json:"vnc,omitempty"`
+	VPNType       string          `json:"vpn_type,omitempty"`
+	VPNConfigPath string          `json:"vpn_config_path,omitempty"`
+}
+
+func newConnectCommand() *cobra.Command {
+	var opts connectOptions
+
+	cmd := &cobra.Command{
+		Use:   "connect [range-id]",
+		Short: "Get remote access to a range over VNC or VPN",
+		Long:  "Show how to reach a range deployed with VNC or VPN. For VNC the console URL and login are printed, and --open opens the console in your browser. For VPN the client config is saved next to the range keys and the command to bring the tunnel up is printed. Ranges without either are reached over SSH with 'openlabs range key'.",
+		Example: `  # Show the VNC console and save the VPN config of range 12
+  openlabs range connect 12
+
+  # Open the VNC console in the browser
+  openlabs range connect 12 --vnc --open
+
+  # Only fetch the VPN config
+  openlabs range connect 12 --vpn`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rangeID string
+			if len(args) > 0 {
+				rangeID = args[0]
+			}
+			return runConnect(rangeID, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.vncOnly, "vnc", false, "only connect over VNC")
+	cmd.Flags().BoolVar(&opts.vpnOnly, "vpn", false, "only connect over VPN")
+	cmd.Flags().BoolVar(&opts.open, "open", false, "open the VNC console in the default browser")
+	cmd.Flags().BoolVar(&opts.reveal, "reveal", false, "show the VNC password instead of masking it")
+	cmd.MarkFlagsMutuallyExclusive("vnc", "vpn")
+
+	return cmd
+}
+
+func runConnect(rangeIDStr string, opts connectOptions) error {
+	if opts.open && opts.vpnOnly {
+		return fmt.Errorf("--open only applies to VNC")
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeIDOrLast(apiClient, rangeIDStr)
+	if err != nil {
+		return err
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	switch {
+	case (opts.vncOnly || opts.open) && !rangeData.VNC:
+		return fmt.Errorf("range %d (%s) was not deployed with VNC", rangeID, rangeData.Name)
+	case opts.vpnOnly && !rangeData.VPN:
+		return fmt.Errorf("range %d (%s) was not deployed with VPN", rangeID, rangeData.Name)
+	case !rangeData.VNC && !rangeData.VPN:
+		return fmt.Errorf("range %d (%s) has no VNC or VPN access; connect over SSH with the key from 'openlabs range key %d'", rangeID, rangeData.Name, rangeID)
+	}
+
+	info := RangeConnectInfo{RangeID: rangeID}
+
+	if rangeData.VNC && !opts.vpnOnly {
+		vnc, err := apiClient.GetRangeVNCInfo(rangeID)
+		if err != nil {
+			if errors.Is(err, client.ErrNotSupported) {
+				return fmt.Errorf("this server does not provide VNC access details")
+			}
+			return err
+		}
+		info.VNC = vnc
+	}
+
+	if rangeData.VPN && !opts.vncOnly {
+		vpnConfig, err := apiClient.GetRangeVPNConfig(rangeID)
+		if err != nil {
+			if errors.Is(err, client.ErrNotSupported) {
+				return fmt.Errorf("this server does not provide VPN configs")
+			}
+			return err
+		}
+
+		info.VPNType = vpnConfigType(vpnConfig)
+		info.VPNConfigPath, err = saveVPNConfig(rangeID, vpnConfig.Config, info.VPNType, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	shown := info
+	if info.VNC != nil && !opts.reveal {
+		shown.VNC = maskVNCPassword(info.VNC)
+	}
+
+	if !output.IsTable(globalConfig.Format) {
+		if err := output.Display(shown, globalConfig.Format); err != nil {
+			return err
+		}
+	} else {
+		displayConnectInfo(shown)
+		if err := output.WriteAlso(shown); err != nil {
+			return err
+		}
+	}
+
+	if opts.open {
+		return openVNCConsole(info.VNC)
+	}
+
+	return nil
+}
+
+func displayConnectInfo(info RangeConnectInfo) {
+	if info.VNC != nil {
+		displayVNCInfo(info.VNC)
+	}
+
+	if info.VPNConfigPath != "" {
+		if info.VNC != nil {
+			fmt.Println()
+		}
+		progress.ShowSuccess(fmt.Sprintf("%s config saved to %s", vpnTypeName(info.VPNType), info.VPNConfigPath))
+		progress.ShowInfo("Connect with: " + vpnConnectCommand(info.VPNType, info.VPNConfigPath))
+	}
+}
@@ -0,0 +1,46 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+// checkProviderCredentials fails before submission when no cloud credentials are stored for the
+// blueprint's provider, which would otherwise only surface as a failed deploy job. Providers the CLI
+// does not know about are left to the server. An encryption key the server cannot use fails the
+// check too, since the deploy could not decrypt the credentials either.
+func checkProviderCredentials(apiClient *client.Client, provider string) error {
+	provider = strings.ToLower(provider)
+
+	secrets, err := apiClient.GetUserSecrets()
+	if errors.Is(err, client.ErrEncryptionKeyInvalid) {
+		return err
+	}
+	if err != nil {
+		logger.Warn("Skipping credentials check: %v", err)
+		return nil
+	}
+
+	var status client.CloudSecretStatus
+	switch provider {
+	case "aws":
+		status = secrets.AWS
+	case "azure":
+		status = secrets.Azure
+	case "gcp":
+		status = secrets.GCP
+	default:
+		logger.Debug("No credentials check for provider %s", provider)
+		return nil
+	}
+
+	if !status.HasCredentials {
+		return fmt.Errorf("no %s credentials are configured. Run 'openlabs auth secrets %s' first (or pass --skip-cred-check)", strings.ToUpper(provider), provider)
+	}
+
+	return nil
+}
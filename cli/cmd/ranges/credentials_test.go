@@ -0,0 +1,57 @@
+package ranges
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestCheckProviderCredentials(t *testing.T) {
+	const secrets = `{"aws":{"has_credentials":true},"azure":{"has_credentials":false}}`
+
+	tests := []struct {
+		name     string
+		provider string
+		status   int
+		body     string
+		wantErr  string
+		wantKey  bool
+	}{
+		{name: "aws configured", provider: "aws", status: http.StatusOK, body: secrets},
+		{name: "provider case ignored", provider: "AWS", status: http.StatusOK, body: secrets},
+		{name: "azure missing", provider: "azure", status: http.StatusOK, body: secrets, wantErr: "no AZURE credentials are configured. Run 'openlabs auth secrets azure' first (or pass --skip-cred-check)"},
+		{name: "gcp absent from an older server", provider: "gcp", status: http.StatusOK, body: secrets, wantErr: "no GCP credentials are configured"},
+		{name: "unknown provider left to the server", provider: "oracle", status: http.StatusOK, body: secrets},
+		{name: "secrets unavailable", provider: "azure", status: http.StatusInternalServerError, body: `{"detail":"boom"}`},
+		{name: "encryption key missing", provider: "aws", status: http.StatusUnauthorized, body: `{"detail":"Encryption key not found. Please try logging in again."}`, wantKey: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/users/me/secrets" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				testutil.RespondJSON(w, tt.status, tt.body)
+			})
+
+			err := checkProviderCredentials(apiClient, tt.provider)
+			switch {
+			case tt.wantKey:
+				if !errors.Is(err, client.ErrEncryptionKeyInvalid) {
+					t.Fatalf("checkProviderCredentials() error = %v, want ErrEncryptionKeyInvalid", err)
+				}
+			case tt.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("checkProviderCredentials() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("checkProviderCredentials() error = %v", err)
+			}
+		})
+	}
+}
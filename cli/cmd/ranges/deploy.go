@@ -4,62 +4,146 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/audit"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
+type deployOptions struct {
+	name        string
+	description string
+	region      string
+	file        string
+	wait        bool
+	timeout     time.Duration
+	followLogs  bool
+	waitState   string
+	remember    bool
+	autoCleanup bool
+	allowDup    bool
+	uniqueName  bool
+	skipCreds   bool
+	getKey      bool
+	vars        utils.TemplateVars
+}
+
 func newDeployCommand() *cobra.Command {
-	var (
-		name        string
-		description string
-		region      string
-		file        string
-	)
+	var opts deployOptions
 
 	cmd := &cobra.Command{
 		Use:   "deploy [blueprint-id-or-name]",
 		Short: "Deploy a cyber range",
-		Long:  "Deploy a cyber range from a blueprint. Returns immediately with job ID.",
-		Args:  cobra.MaximumNArgs(1),
+		Long:  "Deploy a cyber range from a blueprint. Returns immediately with job ID unless --wait is given.",
+		Example: `  # Deploy blueprint 3, prompting for a name and region
+  openlabs range deploy 3
+
+  # Deploy by blueprint name and wait until the range is ready
+  openlabs range deploy "Red Team Lab" --name lab-1 --region us_east_1 --wait-for-state ready
+
+  # Deploy from a templated request file
+  openlabs range deploy --file deploy.yaml --var region=us_east_2 --wait`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var blueprintRef string
 			if len(args) > 0 {
 				blueprintRef = args[0]
 			}
-			return runDeploy(blueprintRef, name, description, region, file)
+			return runDeploy(blueprintRef, opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&name, "name", "n", "", "name for the deployed range")
-	cmd.Flags().StringVarP(&description, "description", "d", "", "description for the range")
-	cmd.Flags().StringVarP(&region, "region", "r", "us_east_1", "deployment region")
-	cmd.Flags().StringVarP(&file, "file", "f", "", "deploy from JSON/YAML configuration file")
+	cmd.Flags().StringVarP(&opts.name, "name", "n", "", "name for the deployed range")
+	cmd.Flags().StringVarP(&opts.description, "description", "d", "", "description for the range")
+	cmd.Flags().StringVarP(&opts.region, "region", "r", "", "deployment region (prompted when omitted in an interactive session)")
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "deploy from JSON/YAML configuration file")
+	cmd.Flags().StringArrayVar(&opts.vars.Pairs, "var", nil, "template variable for --file as key=value (repeatable)")
+	cmd.Flags().StringVar(&opts.vars.File, "var-file", "", "YAML or JSON file of template variables for --file")
+	cmd.Flags().BoolVarP(&opts.wait, "wait", "w", false, "wait for the deployment job to finish")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Minute, "maximum time to wait when using --wait")
+	cmd.Flags().BoolVar(&opts.followLogs, "follow-logs", false, "stream job logs while waiting (requires --wait)")
+	cmd.Flags().BoolVar(&opts.remember, "remember", false, "save the deployed range ID so 'range status' defaults to it (requires --wait)")
+	cmd.Flags().BoolVar(&opts.getKey, "get-key", false, "save the range's SSH key to the configured key path once it is deployed (requires --wait)")
+	cmd.Flags().BoolVar(&opts.allowDup, "allow-duplicate-name", false, "deploy under the given name without checking for an existing range with the same name")
+	cmd.Flags().BoolVar(&opts.uniqueName, "unique-name", false, "add a numeric suffix when a range with the same name already exists")
+	cmd.Flags().BoolVar(&opts.skipCreds, "skip-cred-check", false, "deploy without checking that credentials are configured for the blueprint's provider")
+	cmd.Flags().BoolVar(&opts.autoCleanup, "auto-cleanup", false, "offer to destroy the range record left behind by a failed deployment (requires --wait)")
+	cmd.Flags().StringVar(&opts.waitState, "wait-for-state", "", "after the job completes, wait until the range reaches this state (e.g. ready); implies --wait")
+
+	_ = cmd.RegisterFlagCompletionFunc("region", completeRegions)
 
 	return cmd
 }
 
-func runDeploy(blueprintRef, name, description, region, file string) error {
+func runDeploy(blueprintRef string, opts deployOptions) error {
+	if opts.waitState != "" {
+		state, err := normalizeRangeState(opts.waitState)
+		if err != nil {
+			return err
+		}
+		opts.waitState = state
+		opts.wait = true
+	}
+
+	if opts.followLogs && !opts.wait {
+		return fmt.Errorf("--follow-logs requires --wait")
+	}
+
+	if opts.remember && !opts.wait {
+		return fmt.Errorf("--remember requires --wait")
+	}
+
+	if opts.getKey && !opts.wait {
+		return fmt.Errorf("--get-key requires --wait")
+	}
+
+	if opts.autoCleanup && !opts.wait {
+		return fmt.Errorf("--auto-cleanup requires --wait")
+	}
+
+	if opts.allowDup && opts.uniqueName {
+		return fmt.Errorf("--allow-duplicate-name and --unique-name cannot be used together")
+	}
+
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
+	name := opts.name
+
 	var request *client.DeployRangeRequest
+	var blueprint *client.BlueprintRange
+
+	if selectMode && (opts.file != "" || blueprintRef != "") {
+		return fmt.Errorf("--select cannot be combined with a blueprint reference or --file")
+	}
 
-	if file != "" {
-		deployConfig, err := loadDeployConfig(file)
+	if opts.file != "" {
+		values, err := opts.vars.Values()
+		if err != nil {
+			return err
+		}
+
+		deployConfig, err := loadDeployConfig(opts.file, values)
 		if err != nil {
 			return err
 		}
 		request = deployConfig
+
+		blueprint, err = apiClient.GetBlueprintRange(request.BlueprintID)
+		if err != nil {
+			return fmt.Errorf("failed to get blueprint: %w", err)
+		}
 	} else {
-		if blueprintRef == "" {
+		if blueprintRef == "" && !selectMode && !utils.IsInteractive() {
 			return fmt.Errorf("blueprint ID/name is required when not using --file")
 		}
 
@@ -68,6 +152,11 @@ func runDeploy(blueprintRef, name, description, region, file string) error {
 			return err
 		}
 
+		blueprint, err = apiClient.GetBlueprintRange(blueprintID)
+		if err != nil {
+			return fmt.Errorf("failed to get blueprint: %w", err)
+		}
+
 		if name == "" {
 			var err error
 			name, err = utils.PromptString("Range name")
@@ -80,26 +169,203 @@ func runDeploy(blueprintRef, name, description, region, file string) error {
 			return err
 		}
 
+		region, err := resolveRegion(apiClient, blueprint.Provider, opts.region)
+		if err != nil {
+			return err
+		}
+
 		request = &client.DeployRangeRequest{
 			Name:        name,
-			Description: description,
+			Description: opts.description,
 			BlueprintID: blueprintID,
 			Region:      region,
 		}
 	}
 
+	if err := validateDeployRequest(apiClient, request, blueprint); err != nil {
+		return err
+	}
+
+	if !opts.allowDup {
+		request.Name = checkDuplicateRangeName(apiClient, request.Name, opts.uniqueName)
+	}
+
+	if !opts.skipCreds {
+		if err := checkProviderCredentials(apiClient, blueprint.Provider); err != nil {
+			return err
+		}
+	}
+
+	if err := checkDeployQuota(apiClient, blueprint); err != nil {
+		return err
+	}
+
+	auditEntry := audit.Entry{
+		Command:       "range deploy",
+		RangeName:     request.Name,
+		BlueprintID:   request.BlueprintID,
+		BlueprintName: blueprint.Name,
+	}
+
 	jobResponse, err := apiClient.DeployRange(request)
 	if err != nil {
+		audit.RecordResult(globalConfig, auditEntry, "", err)
 		return fmt.Errorf("failed to start deployment: %w", err)
 	}
+	auditEntry.JobID = jobResponse.ARQJobID
+
+	hosts := countBlueprintHosts(blueprint)
+	result := newDeployResult(jobResponse, hosts)
 
 	progress.ShowSuccess(fmt.Sprintf("Deployment started (Job ID: %s)", jobResponse.ARQJobID))
+	progress.ShowInfo(result.estimateMessage(hosts))
+	showJobURL(jobResponse.ARQJobID)
+
+	if opts.wait {
+		return waitForDeployment(apiClient, jobResponse.ARQJobID, request.Name, hosts, opts, auditEntry)
+	}
+
+	audit.RecordResult(globalConfig, auditEntry, audit.OutcomeSubmitted, nil)
 	progress.ShowInfo("Use 'openlabs range status' to check deployment progress")
 
-	return output.Display(jobResponse, globalConfig.OutputFormat)
+	return output.Display(result, globalConfig.Format)
 }
 
-func loadDeployConfig(file string) (*client.DeployRangeRequest, error) {
+// waitForDeployment follows the deploy job and shows the range once it is deployed. The job's outcome
+// is added to the audit log as auditEntry.
+func waitForDeployment(apiClient *client.Client, jobID, rangeName string, expectedHosts int, opts deployOptions, auditEntry audit.Entry) error {
+	tracker := progress.NewJobTracker(apiClient)
+	if opts.followLogs {
+		tracker.FollowLogs()
+	}
+	tracker.ReportProgress("provisioned %d/%d hosts", newHostProgress(apiClient, rangeName, expectedHosts).probe)
+
+	job, err := tracker.TrackJob(jobID, "Waiting for deployment...", opts.timeout)
+	if job != nil {
+		auditEntry.RangeID, _ = extractRangeID(job.Result)
+	}
+	audit.RecordResult(globalConfig, auditEntry, audit.OutcomeSucceeded, err)
+	if err != nil {
+		if job != nil && job.Status == "failed" {
+			if cleanupErr := handleFailedDeploy(apiClient, job, rangeName, opts.autoCleanup); cleanupErr != nil {
+				progress.ShowWarning(cleanupErr.Error())
+			}
+		}
+		return fmt.Errorf("deployment did not complete: %w", err)
+	}
+
+	rangeID, ok := extractRangeID(job.Result)
+	if !ok {
+		if opts.waitState != "" {
+			return fmt.Errorf("deployment finished but the job result has no range ID; cannot wait for state %s", opts.waitState)
+		}
+		progress.ShowInfo("Use 'openlabs range list' to find the deployed range")
+		return nil
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Range ID: %d", rangeID))
+
+	if opts.remember {
+		rememberRange(rangeID)
+	}
+
+	var rangeData *client.DeployedRange
+	if opts.waitState != "" {
+		rangeData, err = waitForRangeState(apiClient, rangeID, opts.waitState, opts.timeout)
+		if err != nil {
+			return err
+		}
+		progress.ShowSuccess(fmt.Sprintf("Range %d is %s", rangeID, rangeData.State))
+	} else {
+		rangeData, err = apiClient.GetRange(rangeID)
+		if err != nil {
+			return fmt.Errorf("failed to get range details: %w", err)
+		}
+	}
+
+	if opts.getKey {
+		getKeyAfterDeploy(apiClient, rangeData, opts.timeout)
+	}
+
+	if output.IsTable(globalConfig.Format) {
+		displayRangeStatus(rangeData)
+		return output.WriteAlso(rangeData)
+	}
+
+	return output.Display(rangeData, globalConfig.Format)
+}
+
+// checkDuplicateRangeName warns when a range named name already exists, since range commands cannot
+// tell same-named ranges apart by name. It returns the name to deploy under: a suffixed one when
+// uniqueName is set or the user accepts it, otherwise name unchanged.
+func checkDuplicateRangeName(apiClient *client.Client, name string, uniqueName bool) string {
+	ranges, err := apiClient.ListRanges()
+	if err != nil {
+		logger.Debug("Skipping duplicate range name check: %v", err)
+		return name
+	}
+
+	existing := make(map[string]bool, len(ranges))
+	for _, r := range ranges {
+		existing[strings.ToLower(r.Name)] = true
+	}
+
+	suggested := utils.UniqueName(name, existing)
+	if suggested == name {
+		return name
+	}
+
+	if uniqueName {
+		progress.ShowInfo(fmt.Sprintf("Range name '%s' already exists, using '%s'", name, suggested))
+		return suggested
+	}
+
+	progress.ShowWarning(fmt.Sprintf("A range named '%s' already exists; commands will need range IDs to tell them apart", name))
+
+	if utils.IsInteractive() {
+		rename, err := utils.PromptConfirm(fmt.Sprintf("Deploy as '%s' instead?", suggested))
+		if err == nil && rename {
+			return suggested
+		}
+	}
+
+	return name
+}
+
+// handleFailedDeploy looks for a range record that a failed deploy job left behind, which may still
+// own cloud resources. It suggests destroying the range, or with autoCleanup offers to do it after
+// confirmation.
+func handleFailedDeploy(apiClient *client.Client, job *client.Job, rangeName string, autoCleanup bool) error {
+	ranges, err := apiClient.ListRanges()
+	if err != nil {
+		return fmt.Errorf("could not check for a partially deployed range: %w", err)
+	}
+
+	var leftover *client.DeployedRangeHeader
+	for i, r := range ranges {
+		// Ignore an older range that happens to share the name
+		if r.Name == rangeName && !r.Date.Before(job.EnqueueTime) {
+			leftover = &ranges[i]
+			break
+		}
+	}
+
+	if leftover == nil {
+		progress.ShowInfo("No range record was left behind by the failed deployment")
+		return nil
+	}
+
+	progress.ShowWarning(fmt.Sprintf("The failed deployment left range %d (%s) in state %s; its cloud resources may still incur charges", leftover.ID, leftover.Name, leftover.State))
+
+	if !autoCleanup {
+		progress.ShowInfo(fmt.Sprintf("Run 'openlabs range destroy %d' to clean up", leftover.ID))
+		return nil
+	}
+
+	return runDestroy(strconv.Itoa(leftover.ID), false, false, 0, "")
+}
+
+func loadDeployConfig(file string, vars map[string]string) (*client.DeployRangeRequest, error) {
 	if err := utils.ValidateFileExists(file); err != nil {
 		return nil, err
 	}
@@ -108,14 +374,21 @@ func loadDeployConfig(file string) (*client.DeployRangeRequest, error) {
 		return nil, err
 	}
 
+	data, err := utils.ReadTemplatedFile(file, vars)
+	if err != nil {
+		return nil, err
+	}
+
 	var config client.DeployRangeRequest
-	if err := utils.ReadFileAsStructured(file, &config); err != nil {
+	if err := utils.UnmarshalStructured(file, data, &config); err != nil {
 		return nil, err
 	}
 
 	return &config, nil
 }
 
+// resolveBlueprintReference turns a blueprint ID or name into an ID. An empty reference lets the
+// user pick the blueprint from a menu.
 func resolveBlueprintReference(apiClient *client.Client, ref string) (int, error) {
 	if id, err := strconv.Atoi(ref); err == nil {
 		return id, nil
@@ -126,17 +399,36 @@ func resolveBlueprintReference(apiClient *client.Client, ref string) (int, error
 		return 0, fmt.Errorf("failed to list blueprints: %w", err)
 	}
 
+	if ref == "" {
+		if len(blueprints) == 0 {
+			return 0, fmt.Errorf("no blueprints found")
+		}
+
+		options := make([]string, len(blueprints))
+		for i, bp := range blueprints {
+			options[i] = fmt.Sprintf("%s (ID: %d, %s)", bp.Name, bp.ID, bp.Provider)
+		}
+
+		index, err := utils.SelectIndex("Select a blueprint", options)
+		if err != nil {
+			return 0, err
+		}
+		return blueprints[index].ID, nil
+	}
+
 	var matches []client.BlueprintRangeHeader
 	refLower := strings.ToLower(ref)
 
-	for _, bp := range blueprints {
+	names := make([]string, len(blueprints))
+	for i, bp := range blueprints {
+		names[i] = bp.Name
 		if strings.ToLower(bp.Name) == refLower {
 			matches = append(matches, bp)
 		}
 	}
 
 	if len(matches) == 0 {
-		return 0, fmt.Errorf("no blueprint found with name '%s'", ref)
+		return 0, fmt.Errorf("no blueprint found with name '%s'%s", ref, utils.DidYouMean(ref, names))
 	}
 
 	if len(matches) > 1 {
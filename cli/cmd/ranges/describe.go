@@ -0,0 +1,165 @@
+package ranges
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+)
+
+// RangeDescription is a range's current state together with the job events that led to it.
+type RangeDescription struct {
+	ID       int          `json:"id"`
+	Name     string       `json:"name"`
+	Provider string       `json:"provider"`
+	Region   string       `json:"region"`
+	State    string       `json:"state"`
+	Created  time.Time    `json:"created"`
+	Hosts    int          `json:"hosts"`
+	Events   []RangeEvent `json:"events"`
+}
+
+// RangeEvent is one step in the lifecycle of a range job.
+type RangeEvent struct {
+	Time   time.Time `json:"time"`
+	JobID  string    `json:"job_id"`
+	Type   string    `json:"type"`
+	Event  string    `json:"event"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+func newDescribeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe [range-id]",
+		Short: "Show a range with its job timeline",
+		Long:  "Display a range's current state together with the deploy and destroy jobs that affected it, in the order they happened.",
+		Example: `  openlabs range describe 42
+  openlabs range describe my-range --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDescribe(args[0])
+		},
+	}
+}
+
+func runDescribe(rangeIDStr string) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeID(apiClient, rangeIDStr)
+	if err != nil {
+		return err
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	jobs, err := apiClient.ListJobs("")
+	if err != nil {
+		return err
+	}
+
+	description := RangeDescription{
+		ID:       rangeData.ID,
+		Name:     rangeData.Name,
+		Provider: rangeData.Provider,
+		Region:   rangeData.Region,
+		State:    rangeData.State,
+		Created:  rangeData.Date,
+		Events:   rangeEvents(rangeData, jobs),
+	}
+	for _, vpc := range rangeData.VPCs {
+		for _, subnet := range vpc.Subnets {
+			description.Hosts += len(subnet.Hosts)
+		}
+	}
+
+	if output.IsTable(globalConfig.Format) {
+		fmt.Print(formatRangeDescription(&description))
+		return output.WriteAlso(description)
+	}
+
+	return output.Display(description, globalConfig.Format)
+}
+
+// rangeEvents returns the queued, started, and finished events of every range job that belongs to
+// rangeData, oldest first. Jobs whose result carries a range ID are matched on it; jobs without one,
+// such as failed deploys, are matched on the range name.
+func rangeEvents(rangeData *client.DeployedRange, jobs []client.Job) []RangeEvent {
+	events := []RangeEvent{}
+
+	for _, job := range jobs {
+		if !isRangeJob(job.JobName) || !jobBelongsToRange(&job, rangeData) {
+			continue
+		}
+
+		jobType := getJobType(job.JobName)
+		events = append(events, RangeEvent{Time: job.EnqueueTime, JobID: job.ARQJobID, Type: jobType, Event: "queued"})
+
+		if job.StartTime != nil {
+			events = append(events, RangeEvent{Time: *job.StartTime, JobID: job.ARQJobID, Type: jobType, Event: "started"})
+		}
+
+		if job.FinishTime != nil {
+			finished := RangeEvent{Time: *job.FinishTime, JobID: job.ARQJobID, Type: jobType, Event: job.Status}
+			if job.Status == "failed" {
+				finished.Detail = job.ErrorMessage
+			}
+			events = append(events, finished)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	return events
+}
+
+func jobBelongsToRange(job *client.Job, rangeData *client.DeployedRange) bool {
+	if id, ok := extractRangeID(job.Result); ok {
+		return id == rangeData.ID
+	}
+
+	name := extractRangeName(job.Result)
+	return name != "" && strings.EqualFold(name, rangeData.Name)
+}
+
+// formatRangeDescription renders a range description as a readable block with the timeline last.
+func formatRangeDescription(d *RangeDescription) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Range:    %s (ID: %d)\n", d.Name, d.ID)
+	fmt.Fprintf(&b, "State:    %s\n", d.State)
+	fmt.Fprintf(&b, "Provider: %s\n", d.Provider)
+	fmt.Fprintf(&b, "Region:   %s\n", d.Region)
+	fmt.Fprintf(&b, "Hosts:    %d\n", d.Hosts)
+	fmt.Fprintf(&b, "Created:  %s\n", output.FormatTime(d.Created))
+
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "Events:")
+
+	if len(d.Events) == 0 {
+		fmt.Fprintln(&b, "  No jobs found for this range (job records may have been pruned)")
+		return b.String()
+	}
+
+	for _, event := range d.Events {
+		fmt.Fprintf(&b, "  %s  %s job %s %s\n", output.FormatTime(event.Time), event.Type, event.JobID, event.Event)
+		if event.Detail != "" {
+			fmt.Fprintf(&b, "      %s\n", event.Detail)
+		}
+	}
+
+	return b.String()
+}
@@ -1,37 +1,58 @@
 package ranges
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/audit"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
 func newDestroyCommand() *cobra.Command {
-	var force bool
+	var (
+		force     bool
+		wait      bool
+		timeout   time.Duration
+		backupDir string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "destroy [range-id]",
 		Short: "Destroy a deployed range",
-		Long:  "Permanently destroy a deployed range and all its resources. Returns immediately with job ID.",
-		Args:  cobra.MaximumNArgs(1),
+		Long:  "Permanently destroy a deployed range and all its resources. Returns immediately with job ID unless --wait is given. Destroying a range ID that no longer exists succeeds, so cleanup scripts can be rerun. With --backup, the range's definition and state are first saved to a file for auditing.",
+		Example: `  # Destroy range 12 after confirming
+  openlabs range destroy 12
+
+  # Destroy without prompting and wait until it is gone
+  openlabs range destroy 12 --force --wait --timeout 20m
+
+  # Keep a record of the range before destroying it
+  openlabs range destroy 12 --backup ~/openlabs-backups`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var rangeID string
 			if len(args) > 0 {
 				rangeID = args[0]
 			}
-			return runDestroy(rangeID, force)
+			return runDestroy(rangeID, force, wait, timeout, backupDir)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "skip confirmation prompt")
+	cmd.Flags().BoolVarP(&wait, "wait", "w", false, "wait for the destroy job to finish and verify the range is gone")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "maximum time to wait when using --wait")
+	cmd.Flags().StringVar(&backupDir, "backup", "", "save the range's definition and state to this directory before destroying it")
 
 	return cmd
 }
 
-func runDestroy(rangeIDStr string, force bool) error {
+func runDestroy(rangeIDStr string, force, wait bool, timeout time.Duration, backupDir string) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
@@ -54,13 +75,80 @@ func runDestroy(rangeIDStr string, force bool) error {
 		}
 	}
 
+	if backupDir != "" {
+		if err := backupRange(apiClient, rangeID, backupDir); err != nil {
+			return err
+		}
+	}
+
+	auditEntry := audit.Entry{Command: "range destroy", RangeID: rangeID}
+	if _, err := strconv.Atoi(rangeIDStr); err != nil {
+		auditEntry.RangeName = rangeIDStr
+	}
+
 	jobResponse, err := apiClient.DeleteRange(rangeID)
 	if err != nil {
+		// Destroying a range that is already gone succeeds, so cleanup scripts can safely rerun
+		if errors.Is(err, client.ErrNotFound) {
+			progress.ShowInfo(fmt.Sprintf("Range %d not found; it has already been destroyed", rangeID))
+			return nil
+		}
+		audit.RecordResult(globalConfig, auditEntry, "", err)
 		return fmt.Errorf("failed to start destruction: %w", err)
 	}
+	auditEntry.JobID = jobResponse.ARQJobID
 
 	progress.ShowSuccess(fmt.Sprintf("Destruction started (Job ID: %s)", jobResponse.ARQJobID))
+	showJobURL(jobResponse.ARQJobID)
+
+	if wait {
+		err := waitForDestroy(apiClient, rangeID, jobResponse.ARQJobID, timeout)
+		audit.RecordResult(globalConfig, auditEntry, audit.OutcomeSucceeded, err)
+		return err
+	}
+
+	audit.RecordResult(globalConfig, auditEntry, audit.OutcomeSubmitted, nil)
 	progress.ShowInfo("Use 'openlabs range status' to check destruction progress")
 
 	return nil
 }
+
+// backupRange saves a range's definition and state to a timestamped file in dir before it is
+// destroyed. A range that no longer exists has nothing to back up and only produces a warning.
+func backupRange(apiClient *client.Client, rangeID int, dir string) error {
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			progress.ShowWarning(fmt.Sprintf("Range %d not found; nothing to back up", rangeID))
+			return nil
+		}
+		return fmt.Errorf("failed to back up range: %w", err)
+	}
+
+	path := utils.BackupPath(dir, fmt.Sprintf("range-%d", rangeID))
+	if err := utils.WriteJSONToFile(path, rangeData); err != nil {
+		return fmt.Errorf("failed to back up range: %w", err)
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Range %d backed up to %s", rangeID, path))
+	return nil
+}
+
+func waitForDestroy(apiClient *client.Client, rangeID int, jobID string, timeout time.Duration) error {
+	tracker := progress.NewJobTracker(apiClient)
+	if _, err := tracker.TrackJob(jobID, "Waiting for destruction...", timeout); err != nil {
+		return fmt.Errorf("destruction did not complete: %w", err)
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			progress.ShowSuccess(fmt.Sprintf("Range %d fully destroyed", rangeID))
+			return nil
+		}
+		return fmt.Errorf("failed to verify range removal: %w", err)
+	}
+
+	progress.ShowWarning(fmt.Sprintf("Range %d still exists after destruction (state: %s)", rangeID, rangeData.State))
+	return fmt.Errorf("range %d was not removed", rangeID)
+}
@@ -0,0 +1,63 @@
+package ranges
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestDestroyAlreadyDeletedRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		deleteStatus int
+		deleteBody   string
+		backup       bool
+		wantErr      error
+	}{
+		{name: "range already gone", deleteStatus: http.StatusNotFound, deleteBody: `{"detail":"Range 7 not found!"}`},
+		{name: "range already gone with backup", deleteStatus: http.StatusNotFound, deleteBody: `{"detail":"Range 7 not found!"}`, backup: true},
+		{name: "destruction submitted", deleteStatus: http.StatusOK, deleteBody: `{"arq_job_id":"job-1","detail":"Submitted"}`},
+		{name: "server error", deleteStatus: http.StatusInternalServerError, deleteBody: `{"detail":"boom"}`, wantErr: client.ErrServer},
+		{name: "not allowed", deleteStatus: http.StatusForbidden, deleteBody: `{"detail":"Not your range"}`, wantErr: client.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deletes atomic.Int32
+			newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/ranges/7":
+					deletes.Add(1)
+					testutil.RespondJSON(w, tt.deleteStatus, tt.deleteBody)
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v1/ranges/7":
+					testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Range 7 not found!"}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			})
+
+			backupDir := ""
+			if tt.backup {
+				backupDir = t.TempDir()
+			}
+
+			err := runDestroy("7", true, false, 0, backupDir)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !strings.Contains(err.Error(), "failed to start destruction") {
+					t.Fatalf("runDestroy() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("runDestroy() error = %v", err)
+			}
+
+			if n := deletes.Load(); n != 1 {
+				t.Errorf("sent %d delete requests, want 1", n)
+			}
+		})
+	}
+}
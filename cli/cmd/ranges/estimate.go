@@ -0,0 +1,52 @@
+package ranges
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+)
+
+const (
+	estimateSourceServer    = "server"
+	estimateSourceHeuristic = "heuristic"
+)
+
+// DeployResult is what deploy reports for a submitted deployment: its job and when the range is
+// expected to be ready. The estimate comes from the server when it gives one and otherwise from the
+// blueprint's host count.
+type DeployResult struct {
+	ARQJobID         string    `json:"arq_job_id"`
+	Detail           string    `json:"detail"`
+	EstimatedMinutes int       `json:"estimated_minutes"`
+	EstimatedReadyAt time.Time `json:"estimated_ready_at"`
+	EstimateSource   string    `json:"estimate_source"`
+}
+
+func newDeployResult(response *client.JobSubmissionResponse, hosts int) DeployResult {
+	estimate := time.Duration(response.EstimatedSeconds) * time.Second
+	source := estimateSourceServer
+	if estimate <= 0 {
+		estimate = globalConfig.DeployEstimate(hosts)
+		source = estimateSourceHeuristic
+	}
+
+	return DeployResult{
+		ARQJobID:         response.ARQJobID,
+		Detail:           response.Detail,
+		EstimatedMinutes: int(math.Ceil(estimate.Minutes())),
+		EstimatedReadyAt: time.Now().Add(estimate).Truncate(time.Second),
+		EstimateSource:   source,
+	}
+}
+
+// estimateMessage describes the estimate for people, saying where it came from since the heuristic
+// can be far off.
+func (r DeployResult) estimateMessage(hosts int) string {
+	basis := "server estimate"
+	if r.EstimateSource == estimateSourceHeuristic {
+		basis = fmt.Sprintf("rough estimate for %d hosts", hosts)
+	}
+	return fmt.Sprintf("Estimated ready in ~%d minutes (%s)", r.EstimatedMinutes, basis)
+}
@@ -0,0 +1,19 @@
+package ranges
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+// newTestClient returns a client for a fake API served by handler and makes its config the package's.
+// Per-run caches are reset so earlier tests do not leak into it.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *client.Client {
+	t.Helper()
+
+	regionCache = map[string][]string{}
+	globalConfig = testutil.FakeAPI(t, handler)
+	return client.New(globalConfig)
+}
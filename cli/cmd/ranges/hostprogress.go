@@ -0,0 +1,114 @@
+package ranges
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+// hostProgress counts the provisioned hosts of a range while its deployment job runs. The range is
+// found by name among ranges that did not exist when the deployment started. Until the server lists
+// it, the probe reports no count and the job tracker keeps its plain spinner.
+type hostProgress struct {
+	apiClient     *client.Client
+	rangeName     string
+	expectedHosts int
+	existing      map[int]bool
+	rangeID       int
+	disabled      bool
+}
+
+func newHostProgress(apiClient *client.Client, rangeName string, expectedHosts int) *hostProgress {
+	p := &hostProgress{
+		apiClient:     apiClient,
+		rangeName:     rangeName,
+		expectedHosts: expectedHosts,
+		existing:      make(map[int]bool),
+	}
+
+	ranges, err := apiClient.ListRanges()
+	if err != nil {
+		logger.Debug("Host progress disabled, cannot list ranges: %v", err)
+		p.disabled = true
+		return p
+	}
+	for _, r := range ranges {
+		p.existing[r.ID] = true
+	}
+
+	return p
+}
+
+func (p *hostProgress) probe() (int, int, bool) {
+	if p.disabled {
+		return 0, 0, false
+	}
+
+	if p.rangeID == 0 {
+		ranges, err := p.apiClient.ListRanges()
+		if err != nil {
+			logger.Debug("Host progress: failed to list ranges: %v", err)
+			return 0, 0, false
+		}
+		for _, r := range ranges {
+			if !p.existing[r.ID] && strings.EqualFold(r.Name, p.rangeName) {
+				p.rangeID = r.ID
+				break
+			}
+		}
+		if p.rangeID == 0 {
+			return 0, 0, false
+		}
+	}
+
+	rangeData, err := p.apiClient.GetRange(p.rangeID)
+	if err != nil {
+		logger.Debug("Host progress: failed to get range %d: %v", p.rangeID, err)
+		if errors.Is(err, client.ErrNotFound) {
+			p.rangeID = 0
+		}
+		return 0, 0, false
+	}
+
+	done, total := countProvisionedHosts(rangeData)
+	if total == 0 {
+		return 0, 0, false
+	}
+
+	// Hosts may only be listed once they are created, so the blueprint knows the real total
+	if p.expectedHosts > total {
+		total = p.expectedHosts
+	}
+
+	return done, total, true
+}
+
+// countProvisionedHosts counts a range's hosts and how many are provisioned: those the server marks
+// ready, or, when it reports no host status, those that have an IP address.
+func countProvisionedHosts(rangeData *client.DeployedRange) (int, int) {
+	done, total := 0, 0
+	for _, vpc := range rangeData.VPCs {
+		for _, subnet := range vpc.Subnets {
+			for _, host := range subnet.Hosts {
+				total++
+				if hostProvisioned(host) {
+					done++
+				}
+			}
+		}
+	}
+	return done, total
+}
+
+func hostProvisioned(host client.DeployedHost) bool {
+	switch strings.ToLower(host.Status) {
+	case "":
+		return host.IPAddress != ""
+	case "ready", "running", "on":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,152 @@
+package ranges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+// JobLogOutput is the log output of a job, as printed by 'range jobs logs' outside table format.
+type JobLogOutput struct {
+	JobID        string   `json:"job_id"`
+	Status       string   `json:"status"`
+	Lines        []string `json:"lines"`
+	ErrorMessage string   `json:"error_message,omitempty"`
+}
+
+func newJobsLogsCommand() *cobra.Command {
+	var follow bool
+
+	cmd := &cobra.Command{
+		Use:   "logs [job-id]",
+		Short: "Show a job's log output",
+		Long:  "Print the log output of a range job. For a failed deploy this shows the full error detail behind the job's short error message. Use --follow to stream the output of a job that is still running until it finishes.",
+		Example: `  openlabs range jobs logs 4f2a9c
+  openlabs range jobs logs 4f2a9c --follow`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobsLogs(args[0], follow)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "keep printing new output until the job finishes")
+
+	return cmd
+}
+
+func runJobsLogs(jobID string, follow bool) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	job, err := apiClient.GetJob(jobID)
+	if err != nil {
+		return err
+	}
+
+	if follow && !jobFinished(job) {
+		return followJobLogs(apiClient, jobID)
+	}
+
+	lines, _, err := readJobLogs(apiClient, jobID, 0)
+	if err != nil {
+		return err
+	}
+
+	if !output.IsTable(globalConfig.Format) {
+		return output.Display(JobLogOutput{
+			JobID:        jobID,
+			Status:       job.Status,
+			Lines:        lines,
+			ErrorMessage: job.ErrorMessage,
+		}, globalConfig.Format)
+	}
+
+	if len(lines) == 0 {
+		progress.ShowInfo(fmt.Sprintf("Job %s has no log output", jobID))
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+
+	switch {
+	case job.Status == "failed" && job.ErrorMessage != "":
+		progress.ShowError(fmt.Sprintf("Job failed: %s", job.ErrorMessage))
+	case !jobFinished(job):
+		progress.ShowInfo(fmt.Sprintf("Job %s is still %s; use --follow to stream its output", jobID, job.Status))
+	}
+
+	return nil
+}
+
+// followJobLogs prints a job's log output as it is written and returns once the job has finished
+// and its last lines are printed. A failed job is returned as an error.
+func followJobLogs(apiClient *client.Client, jobID string) error {
+	poller := apiClient.NewJobPoller(jobID, 2*time.Second)
+	poller.DisableLongPoll()
+
+	offset := 0
+	for {
+		job, err := poller.Next(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to check job status: %w", err)
+		}
+
+		var lines []string
+		lines, offset, err = readJobLogs(apiClient, jobID, offset)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+
+		if !jobFinished(job) {
+			continue
+		}
+
+		if job.Status == "failed" {
+			if job.ErrorMessage != "" {
+				return fmt.Errorf("job %s failed: %s", jobID, job.ErrorMessage)
+			}
+			return fmt.Errorf("job %s failed", jobID)
+		}
+
+		progress.ShowSuccess(fmt.Sprintf("Job %s completed", jobID))
+		return nil
+	}
+}
+
+// readJobLogs returns every log line available from offset, fetching further pages for as long as
+// the server keeps advancing the offset, and the offset to continue from.
+func readJobLogs(apiClient *client.Client, jobID string, offset int) ([]string, int, error) {
+	var lines []string
+	for {
+		logs, err := apiClient.GetJobLogs(jobID, offset)
+		if errors.Is(err, client.ErrNotSupported) {
+			return nil, offset, fmt.Errorf("job logs are not available from this server; use 'openlabs range jobs show %s' for the job's status and error", jobID)
+		}
+		if err != nil {
+			return nil, offset, err
+		}
+
+		lines = append(lines, logs.Lines...)
+		if len(logs.Lines) == 0 || logs.NextOffset <= offset {
+			return lines, offset, nil
+		}
+		offset = logs.NextOffset
+	}
+}
+
+func jobFinished(job *client.Job) bool {
+	return job.Status == "complete" || job.Status == "failed"
+}
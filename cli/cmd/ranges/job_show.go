@@ -0,0 +1,81 @@
+package ranges
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+)
+
+func newJobsShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [job-id]",
+		Short: "Show job details",
+		Long:  "Display the status, affected range, and any error for a single job.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobsShow(args[0])
+		},
+	}
+}
+
+func runJobsShow(jobID string) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	job, err := apiClient.GetJob(jobID)
+	if err != nil {
+		return err
+	}
+
+	if output.IsTable(globalConfig.Format) {
+		fmt.Print(formatJobResult(job))
+		return output.WriteAlso(job)
+	}
+
+	return output.Display(job, globalConfig.Format)
+}
+
+// formatJobResult renders a job as a readable block, surfacing the range it produced and any
+// failure message instead of leaving them buried in the raw result.
+func formatJobResult(job *client.Job) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Job: %s (%s)\n", job.ARQJobID, getJobType(job.JobName))
+	fmt.Fprintf(&b, "Status: %s\n", job.Status)
+	if jobURL := globalConfig.JobURL(job.ARQJobID); jobURL != "" {
+		fmt.Fprintf(&b, "URL: %s\n", jobURL)
+	}
+
+	if rangeID, ok := extractRangeID(job.Result); ok {
+		if name := extractRangeName(job.Result); name != "" {
+			fmt.Fprintf(&b, "Range: %s (ID: %d)\n", name, rangeID)
+		} else {
+			fmt.Fprintf(&b, "Range ID: %d\n", rangeID)
+		}
+	}
+
+	fmt.Fprintf(&b, "Queued: %s\n", output.FormatTime(job.EnqueueTime))
+	if job.StartTime != nil {
+		fmt.Fprintf(&b, "Started: %s\n", output.FormatTime(*job.StartTime))
+	}
+	if job.FinishTime != nil {
+		fmt.Fprintf(&b, "Finished: %s\n", output.FormatTime(*job.FinishTime))
+	}
+
+	if job.Status == "failed" {
+		errorMessage := job.ErrorMessage
+		if errorMessage == "" {
+			errorMessage = "no error message reported"
+		}
+		fmt.Fprintf(&b, "Error: %s\n", errorMessage)
+	}
+
+	return b.String()
+}
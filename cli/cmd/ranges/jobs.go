@@ -24,6 +24,11 @@ func newJobsCommand() *cobra.Command {
 
 	cmd.Flags().StringVarP(&status, "status", "s", "", "filter by job status (queued, in_progress, complete, failed)")
 
+	cmd.AddCommand(newJobsShowCommand())
+	cmd.AddCommand(newJobsLogsCommand())
+	cmd.AddCommand(newJobsCancelCommand())
+	cmd.AddCommand(newJobsPruneCommand())
+
 	return cmd
 }
 
@@ -36,12 +41,12 @@ func runJobs(status string) error {
 
 	jobs, err := apiClient.ListJobs(status)
 	if err != nil {
-		// Handle 404 responses that indicate no jobs found
-		if strings.Contains(err.Error(), "HTTP 404") && strings.Contains(err.Error(), "jobs that you own") {
-			fmt.Println("No jobs found.")
-			return nil
-		}
-		return fmt.Errorf("failed to list jobs: %w", err)
+		return err
+	}
+
+	if len(jobs) == 0 {
+		fmt.Println("No jobs found.")
+		return nil
 	}
 
 	// Filter to only range-related jobs
@@ -52,16 +57,16 @@ func runJobs(status string) error {
 				ID:          job.ARQJobID,
 				Type:        getJobType(job.JobName),
 				Status:      job.Status,
-				EnqueueTime: job.EnqueueTime.Format("2006-01-02 15:04:05"),
+				EnqueueTime: output.FormatTime(job.EnqueueTime),
 				RangeName:   extractRangeName(job.Result),
 			}
 
 			if job.StartTime != nil {
-				display.StartTime = job.StartTime.Format("15:04:05")
+				display.StartTime = output.FormatTime(*job.StartTime)
 			}
 
 			if job.FinishTime != nil {
-				display.FinishTime = job.FinishTime.Format("15:04:05")
+				display.FinishTime = output.FormatTime(*job.FinishTime)
 			}
 
 			if job.Status == "failed" && job.ErrorMessage != "" {
@@ -77,7 +82,7 @@ func runJobs(status string) error {
 		return nil
 	}
 
-	return output.Display(rangeJobs, globalConfig.OutputFormat)
+	return output.Display(rangeJobs, globalConfig.Format)
 }
 
 type JobDisplay struct {
@@ -120,7 +125,6 @@ func getJobType(jobName string) string {
 	return "Range"
 }
 
-
 func extractRangeName(result interface{}) string {
 	if result == nil {
 		return ""
@@ -137,3 +141,17 @@ func extractRangeName(result interface{}) string {
 
 	return ""
 }
+
+func extractRangeID(result interface{}) (int, bool) {
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+
+	// JSON numbers decode as float64
+	if id, ok := resultMap["id"].(float64); ok {
+		return int(id), true
+	}
+
+	return 0, false
+}
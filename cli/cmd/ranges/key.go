@@ -1,28 +1,66 @@
 package ranges
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
 )
 
+type keyOptions struct {
+	openssh       bool
+	pem           bool
+	addAgent      bool
+	agentLifetime time.Duration
+}
+
 func newKeyCommand() *cobra.Command {
-	return &cobra.Command{
+	var opts keyOptions
+
+	cmd := &cobra.Command{
 		Use:   "key [range-id]",
 		Short: "Get SSH private key for range",
-		Long:  "Retrieve and save the SSH private key for connecting to range hosts.",
-		Args:  cobra.MaximumNArgs(1),
+		Long:  "Retrieve and save the SSH private key for connecting to range hosts. The key is printed as returned by the server unless a format conversion is requested.",
+		Example: `  # Save the key as returned by the server
+  openlabs range key 12 > range.pem
+
+  # Save the key in OpenSSH format for ssh and ssh-add
+  openlabs range key 12 --openssh > range_key
+
+  # Load the key into the running ssh-agent for an hour instead of writing it to disk
+  openlabs range key 12 --add-agent --agent-lifetime 1h`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var rangeID string
 			if len(args) > 0 {
 				rangeID = args[0]
 			}
-			return runKey(rangeID)
+			return runKey(rangeID, opts)
 		},
 	}
+
+	cmd.Flags().BoolVar(&opts.openssh, "openssh", false, "convert the key to OpenSSH format")
+	cmd.Flags().BoolVar(&opts.pem, "pem", false, "convert the key to traditional PEM format")
+	cmd.Flags().BoolVar(&opts.addAgent, "add-agent", false, "load the key into the running ssh-agent instead of printing it")
+	cmd.Flags().DurationVar(&opts.agentLifetime, "agent-lifetime", 0, "how long ssh-agent keeps the key (default: until the agent exits)")
+	cmd.MarkFlagsMutuallyExclusive("openssh", "pem", "add-agent")
+
+	return cmd
 }
 
-func runKey(rangeIDStr string) error {
+func runKey(rangeIDStr string, opts keyOptions) error {
+	if opts.agentLifetime != 0 && !opts.addAgent {
+		return fmt.Errorf("--agent-lifetime requires --add-agent")
+	}
+
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
@@ -39,6 +77,121 @@ func runKey(rangeIDStr string) error {
 		return fmt.Errorf("failed to get range key: %w", err)
 	}
 
-	fmt.Println(keyResponse.RangePrivateKey)
+	key := keyResponse.RangePrivateKey
+
+	if opts.addAgent {
+		return addKeyToAgent(rangeID, key, opts.agentLifetime)
+	}
+
+	targetFormat := ""
+	switch {
+	case opts.openssh:
+		targetFormat = keyFormatOpenSSH
+	case opts.pem:
+		targetFormat = keyFormatPEM
+	}
+
+	rawKey, parseErr := parseRangeKey(key)
+	if parseErr != nil {
+		if targetFormat != "" {
+			return parseErr
+		}
+		// stderr, so the warning doesn't end up in a redirected key file
+		fmt.Fprintf(os.Stderr, "⚠ %v; it may have been truncated\n", parseErr)
+	}
+
+	if targetFormat != "" && detectKeyFormat(key) != targetFormat {
+		key, err = convertKey(rawKey, targetFormat)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(strings.TrimRight(key, "\n"))
 	return nil
 }
+
+const (
+	keyRetryInterval = 5 * time.Second
+	maxKeyWait       = 2 * time.Minute
+)
+
+// fetchRangeKeyWhenReady gets a range's private key, retrying for a short while because a freshly
+// deployed range may not have its key stored yet.
+func fetchRangeKeyWhenReady(apiClient *client.Client, rangeID int, timeout time.Duration) (string, error) {
+	if timeout > maxKeyWait {
+		timeout = maxKeyWait
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		keyResponse, err := apiClient.GetRangeKey(rangeID)
+		switch {
+		case err == nil && keyResponse.RangePrivateKey != "":
+			return keyResponse.RangePrivateKey, nil
+		case err != nil && !errors.Is(err, client.ErrNotFound):
+			return "", fmt.Errorf("failed to get range key: %w", err)
+		}
+
+		if time.Now().Add(keyRetryInterval).After(deadline) {
+			return "", fmt.Errorf("the key for range %d is not available yet", rangeID)
+		}
+		time.Sleep(keyRetryInterval)
+	}
+}
+
+// keyDirectory returns the directory range keys and connection files are saved to. Configs that
+// predate ssh_key_path use the default directory.
+func keyDirectory() (string, error) {
+	keyDir := globalConfig.SSHKeyPath
+	if keyDir == "" {
+		keyDir = config.DefaultConfig().SSHKeyPath
+	}
+
+	if err := os.MkdirAll(keyDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create key directory: %w", err)
+	}
+	return keyDir, nil
+}
+
+// saveRangeKey writes a range's private key to the key directory, readable only by the user, and
+// returns its path.
+func saveRangeKey(rangeID int, key string) (string, error) {
+	keyDir, err := keyDirectory()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(keyDir, fmt.Sprintf("range-%d.pem", rangeID))
+	if err := os.WriteFile(path, []byte(strings.TrimRight(key, "\n")+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to save range key: %w", err)
+	}
+
+	return path, nil
+}
+
+// getKeyAfterDeploy saves the key of a newly deployed range and shows how to connect. The range is
+// already deployed, so problems are reported as warnings with the command to retry.
+func getKeyAfterDeploy(apiClient *client.Client, rangeData *client.DeployedRange, timeout time.Duration) {
+	var key string
+	err := progress.WithSpinner("Fetching SSH key...", func() error {
+		var err error
+		key, err = fetchRangeKeyWhenReady(apiClient, rangeData.ID, timeout)
+		return err
+	})
+
+	var path string
+	if err == nil {
+		path, err = saveRangeKey(rangeData.ID, key)
+	}
+	if err != nil {
+		progress.ShowWarning(fmt.Sprintf("%v; run 'openlabs range key %d' later to get it", err, rangeData.ID))
+		return
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("SSH key saved to %s", path))
+
+	if user := jumpboxUsers[strings.ToLower(rangeData.Provider)]; user != "" && rangeData.JumpboxPublicIP != "" {
+		progress.ShowInfo(fmt.Sprintf("Connect with: ssh -i %s %s@%s", path, user, rangeData.JumpboxPublicIP))
+	}
+}
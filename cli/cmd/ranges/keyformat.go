@@ -0,0 +1,85 @@
+package ranges
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	keyFormatOpenSSH = "openssh"
+	keyFormatPEM     = "pem"
+)
+
+// detectKeyFormat reports whether a private key is in OpenSSH or traditional PEM encoding, or ""
+// if it is not PEM-armored at all.
+func detectKeyFormat(key string) string {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return ""
+	}
+	if block.Type == "OPENSSH PRIVATE KEY" {
+		return keyFormatOpenSSH
+	}
+	return keyFormatPEM
+}
+
+// parseRangeKey parses a range private key, catching keys that were truncated or corrupted in transit.
+func parseRangeKey(key string) (interface{}, error) {
+	if strings.TrimSpace(key) == "" {
+		return nil, fmt.Errorf("range key is empty")
+	}
+
+	rawKey, err := ssh.ParseRawPrivateKey([]byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("range key is not a valid private key: %w", err)
+	}
+
+	return rawKey, nil
+}
+
+// convertKey re-encodes a parsed private key in the requested format.
+func convertKey(rawKey interface{}, format string) (string, error) {
+	var block *pem.Block
+	var err error
+
+	switch format {
+	case keyFormatOpenSSH:
+		block, err = ssh.MarshalPrivateKey(rawKey, "")
+	case keyFormatPEM:
+		block, err = marshalPEMKey(rawKey)
+	default:
+		return "", fmt.Errorf("unknown key format: %s", format)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to convert key to %s format: %w", format, err)
+	}
+
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+// marshalPEMKey encodes a key the way ssh-keygen -m PEM does: PKCS#1 for RSA and SEC 1 for ECDSA.
+// OpenSSH only reads Ed25519 keys in its own format, so those are rejected.
+func marshalPEMKey(rawKey interface{}) (*pem.Block, error) {
+	switch key := rawKey.(type) {
+	case *rsa.PrivateKey:
+		return &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}, nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}, nil
+	case ed25519.PrivateKey, *ed25519.PrivateKey:
+		return nil, fmt.Errorf("ssh cannot read Ed25519 keys in PEM format; use --openssh")
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", rawKey)
+	}
+}
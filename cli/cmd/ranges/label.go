@@ -0,0 +1,157 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+func newLabelCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "label [range-id] [key=value | key-]...",
+		Short: "Set or remove range labels",
+		Long:  "Add or change labels with key=value and remove them with key-. Labels are free-form and can be used to filter 'range list' with --label.",
+		Example: `  openlabs range label 12 class=2024 team=red
+  openlabs range label my-range team-`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// With --select every argument is a label change and the range comes from the menu
+			if selectMode {
+				return runLabel("", args)
+			}
+			if len(args) < 2 {
+				return fmt.Errorf("requires a range and at least one label change")
+			}
+			return runLabel(args[0], args[1:])
+		},
+	}
+}
+
+func runLabel(rangeIDStr string, changes []string) error {
+	set, remove, err := parseLabelChanges(changes)
+	if err != nil {
+		return err
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeID(apiClient, rangeIDStr)
+	if err != nil {
+		return err
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	labels := make(map[string]string, len(rangeData.Labels)+len(set))
+	for key, value := range rangeData.Labels {
+		labels[key] = value
+	}
+	for key, value := range set {
+		labels[key] = value
+	}
+	for _, key := range remove {
+		delete(labels, key)
+	}
+
+	if err := apiClient.UpdateRangeLabels(rangeID, labels); err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return fmt.Errorf("range labels are not supported by this server")
+		}
+		return err
+	}
+
+	if len(labels) == 0 {
+		progress.ShowSuccess(fmt.Sprintf("Range %d has no labels", rangeID))
+	} else {
+		progress.ShowSuccess(fmt.Sprintf("Labels for range %d: %s", rangeID, formatLabels(labels)))
+	}
+
+	return nil
+}
+
+// parseLabelChanges splits label arguments into labels to set (key=value) and keys to remove (key-).
+func parseLabelChanges(args []string) (map[string]string, []string, error) {
+	set := make(map[string]string)
+	var remove []string
+
+	for _, arg := range args {
+		if key, value, ok := strings.Cut(arg, "="); ok {
+			if err := validateLabelKey(key); err != nil {
+				return nil, nil, err
+			}
+			set[key] = value
+			continue
+		}
+
+		if key, ok := strings.CutSuffix(arg, "-"); ok {
+			if err := validateLabelKey(key); err != nil {
+				return nil, nil, err
+			}
+			remove = append(remove, key)
+			continue
+		}
+
+		return nil, nil, fmt.Errorf("invalid label %q (use key=value to set or key- to remove)", arg)
+	}
+
+	return set, remove, nil
+}
+
+func validateLabelKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("label key cannot be empty")
+	}
+	return nil
+}
+
+// labelSelector matches ranges by label. An empty value only requires the key to be present.
+type labelSelector struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+func parseLabelSelectors(args []string) ([]labelSelector, error) {
+	selectors := make([]labelSelector, 0, len(args))
+	for _, arg := range args {
+		key, value, hasValue := strings.Cut(arg, "=")
+		if err := validateLabelKey(key); err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, labelSelector{key: key, value: value, hasValue: hasValue})
+	}
+	return selectors, nil
+}
+
+// matchesLabels reports whether labels satisfy every selector.
+func matchesLabels(labels map[string]string, selectors []labelSelector) bool {
+	for _, selector := range selectors {
+		value, ok := labels[selector.key]
+		if !ok || (selector.hasValue && value != selector.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
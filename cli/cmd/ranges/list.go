@@ -2,31 +2,76 @@ package ranges
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
+type listOptions struct {
+	pageSize int
+	sortBy   string
+	all      bool
+	labels   []string
+}
+
 func newListCommand() *cobra.Command {
-	return &cobra.Command{
+	var opts listOptions
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List deployed ranges",
-		Long:  "Show all deployed ranges for the current user.",
+		Long: `Show all deployed ranges for the current user.
+
+With --page-size only the first page is shown. Paging and --sort-by are passed to the server; when the
+server does not support them the CLI sorts and pages locally. Against a server that pages but does not
+sort, --sort-by only orders the page that was fetched unless --all fetches every page.`,
+		Example: `  openlabs range list --sort-by -date
+  openlabs range list --page-size 20 --sort-by name --all
+  openlabs range list --label team=red --label class`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList()
+			return runList(opts)
 		},
 	}
+
+	cmd.Flags().IntVar(&opts.pageSize, "page-size", 0, "number of ranges per page")
+	cmd.Flags().StringVar(&opts.sortBy, "sort-by", "", "field to sort by, prefixed with - for descending (e.g. name, -date)")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "fetch every page when --page-size is set")
+	cmd.Flags().StringArrayVar(&opts.labels, "label", nil, "only show ranges with this label, as key=value or key (repeatable)")
+
+	return cmd
 }
 
-func runList() error {
+func runList(opts listOptions) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
-	ranges, err := apiClient.ListRanges()
+	if opts.pageSize < 0 {
+		return fmt.Errorf("--page-size must be positive")
+	}
+
+	selectors, err := parseLabelSelectors(opts.labels)
+	if err != nil {
+		return err
+	}
+
+	if opts.sortBy != "" {
+		if err := utils.ValidateSortField(client.DeployedRangeHeader{}, opts.sortBy); err != nil {
+			return err
+		}
+	}
+
+	ranges, err := apiClient.ListRangesWithOptions(client.ListOptions{
+		PageSize: opts.pageSize,
+		Sort:     opts.sortBy,
+		All:      opts.all,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to list ranges: %w", err)
 	}
@@ -36,5 +81,26 @@ func runList() error {
 		return nil
 	}
 
-	return output.Display(ranges, globalConfig.OutputFormat)
+	if len(selectors) > 0 {
+		ranges = slices.DeleteFunc(ranges, func(r client.DeployedRangeHeader) bool {
+			return !matchesLabels(r.Labels, selectors)
+		})
+		if len(ranges) == 0 {
+			fmt.Println("No ranges match the given labels")
+			return nil
+		}
+	}
+
+	if opts.sortBy != "" {
+		if err := utils.SortByField(ranges, opts.sortBy); err != nil {
+			return err
+		}
+	}
+
+	// A server without paging returns everything, so trim to the requested page locally
+	if opts.pageSize > 0 && !opts.all && len(ranges) > opts.pageSize {
+		ranges = ranges[:opts.pageSize]
+	}
+
+	return output.Display(ranges, globalConfig.Format)
 }
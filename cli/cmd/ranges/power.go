@@ -0,0 +1,138 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+type powerOptions struct {
+	action  string
+	force   bool
+	wait    bool
+	timeout time.Duration
+}
+
+func newPowerCommand() *cobra.Command {
+	var opts powerOptions
+
+	cmd := &cobra.Command{
+		Use:   "power [range-id] <host>",
+		Short: "Reboot, stop, or start a host in a range",
+		Long:  "Run a power operation on one host of a deployed range. The host is given by hostname or host ID. When only the host is given, the range defaults to the one saved by 'range deploy --remember'.",
+		Example: `  # Reboot the web server in range 12
+  openlabs range power 12 web-01 --action reboot
+
+  # Stop a host without confirming and wait for the operation to finish
+  openlabs range power 12 web-01 --action stop --force --wait`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rangeRef string
+			if len(args) == 2 {
+				rangeRef = args[0]
+			}
+			return runPower(rangeRef, args[len(args)-1], opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.action, "action", "a", "reboot", "power operation: reboot, stop, or start")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "skip the confirmation prompt for stop")
+	cmd.Flags().BoolVarP(&opts.wait, "wait", "w", false, "wait for the operation to finish when the server runs it as a job")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 10*time.Minute, "maximum time to wait when using --wait")
+
+	_ = cmd.RegisterFlagCompletionFunc("action", cobra.FixedCompletions([]string{"reboot", "stop", "start"}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+func runPower(rangeRef, hostRef string, opts powerOptions) error {
+	var operation func(*client.Client, int, int) (*client.HostPowerResponse, error)
+	switch opts.action {
+	case "reboot":
+		operation = (*client.Client).RebootHost
+	case "stop":
+		operation = (*client.Client).StopHost
+	case "start":
+		operation = (*client.Client).StartHost
+	default:
+		return fmt.Errorf("invalid action: %s (valid: reboot, stop, start)", opts.action)
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeIDOrLast(apiClient, rangeRef)
+	if err != nil {
+		return err
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	host, err := findHost(rangeData, hostRef)
+	if err != nil {
+		return err
+	}
+
+	if opts.action == "stop" && !opts.force {
+		confirmed, err := utils.PromptConfirm(fmt.Sprintf("Stop host %s in range %d?", host.Hostname, rangeID))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			progress.ShowInfo("Stop cancelled")
+			return nil
+		}
+	}
+
+	response, err := operation(apiClient, rangeID, host.ID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return fmt.Errorf("host power operations are not supported by this server")
+		}
+		return err
+	}
+
+	if response.ARQJobID == "" {
+		if !output.IsTable(globalConfig.Format) {
+			return output.Display(response, globalConfig.Format)
+		}
+		if response.State != "" {
+			progress.ShowSuccess(fmt.Sprintf("Host %s is %s", host.Hostname, response.State))
+		} else {
+			progress.ShowSuccess(fmt.Sprintf("Sent %s to host %s", opts.action, host.Hostname))
+		}
+		return output.WriteAlso(response)
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Host %s %s started (Job ID: %s)", host.Hostname, opts.action, response.ARQJobID))
+	showJobURL(response.ARQJobID)
+
+	if !opts.wait {
+		if !output.IsTable(globalConfig.Format) {
+			return output.Display(response, globalConfig.Format)
+		}
+		progress.ShowInfo(fmt.Sprintf("Use 'openlabs range jobs show %s' to check progress", response.ARQJobID))
+		return output.WriteAlso(response)
+	}
+
+	tracker := progress.NewJobTracker(apiClient)
+	if _, err := tracker.TrackJob(response.ARQJobID, fmt.Sprintf("Waiting for %s of %s...", opts.action, host.Hostname), opts.timeout); err != nil {
+		return fmt.Errorf("%s of host %s did not complete: %w", opts.action, host.Hostname, err)
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Host %s %s completed", host.Hostname, opts.action))
+	return nil
+}
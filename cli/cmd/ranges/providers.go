@@ -0,0 +1,140 @@
+package ranges
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+// deployProvider holds what the CLI knows about deploying to one cloud provider. To support a new
+// provider, add an entry to deployProviders.
+type deployProvider struct {
+	name          string
+	label         string
+	defaultRegion string
+	// regions mirrors the API's supported regions for servers that don't publish a region list
+	regions []string
+	// specs maps each host spec the provider can run to the instance size the API provisions for it
+	specs map[string]string
+}
+
+var deployProviders = []deployProvider{
+	{
+		name:          "aws",
+		label:         "AWS",
+		defaultRegion: "us_east_1",
+		regions:       []string{"us_east_1", "us_east_2"},
+		specs: map[string]string{
+			"tiny":   "t2.micro",
+			"small":  "t2.small",
+			"medium": "t2.medium",
+			"large":  "t2.large",
+			"huge":   "t2.xlarge",
+		},
+	},
+	{
+		name:          "azure",
+		label:         "Azure",
+		defaultRegion: "us_east_1",
+		regions:       []string{"us_east_1", "us_east_2"},
+		specs: map[string]string{
+			"tiny":   "Standard_B1s",
+			"small":  "Standard_B1ms",
+			"medium": "Standard_B2s",
+			"large":  "Standard_B2ms",
+			"huge":   "Standard_B4ms",
+		},
+	},
+}
+
+// findDeployProvider returns the rules for provider, or false when the CLI has none and leaves
+// validation to the server.
+func findDeployProvider(provider string) (*deployProvider, bool) {
+	for i := range deployProviders {
+		if strings.EqualFold(deployProviders[i].name, provider) {
+			return &deployProviders[i], true
+		}
+	}
+	return nil, false
+}
+
+// defaultRegionFor returns the region used when none is given for provider.
+func defaultRegionFor(provider string) string {
+	if p, ok := findDeployProvider(provider); ok {
+		return p.defaultRegion
+	}
+	return defaultRegion
+}
+
+// fallbackRegionsFor returns the built-in region list for provider.
+func fallbackRegionsFor(provider string) []string {
+	if p, ok := findDeployProvider(provider); ok {
+		return p.regions
+	}
+	return fallbackRegions
+}
+
+// validateDeployRequest applies the provider's default region to request and checks the region and
+// the blueprint's host specs against what the provider supports, so mismatches fail before the
+// deployment is submitted.
+func validateDeployRequest(apiClient *client.Client, request *client.DeployRangeRequest, blueprint *client.BlueprintRange) error {
+	p, ok := findDeployProvider(blueprint.Provider)
+	if !ok {
+		logger.Debug("No deploy rules for provider %q, leaving validation to the server", blueprint.Provider)
+		return nil
+	}
+
+	if request.Region == "" {
+		request.Region = p.defaultRegion
+	}
+
+	regions, err := getValidRegions(apiClient, p.name)
+	if err != nil {
+		return err
+	}
+	region, ok := matchFold(regions, request.Region)
+	if !ok {
+		return fmt.Errorf("invalid region '%s' for provider %s (valid: %s)", request.Region, p.name, strings.Join(regions, ", "))
+	}
+	request.Region = region
+
+	return p.checkSpecs(blueprint)
+}
+
+// checkSpecs reports every blueprint host whose spec the provider cannot run.
+func (p *deployProvider) checkSpecs(blueprint *client.BlueprintRange) error {
+	var invalid []string
+	for _, vpc := range blueprint.VPCs {
+		for _, subnet := range vpc.Subnets {
+			for _, host := range subnet.Hosts {
+				if _, ok := p.specs[strings.ToLower(host.Spec)]; !ok {
+					invalid = append(invalid, fmt.Sprintf("%s (%s)", host.Hostname, host.Spec))
+				}
+			}
+		}
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	valid := make([]string, 0, len(p.specs))
+	for spec := range p.specs {
+		valid = append(valid, spec)
+	}
+	sort.Strings(valid)
+
+	return fmt.Errorf("%s cannot run the spec of these hosts: %s (valid specs: %s)", p.label, strings.Join(invalid, ", "), strings.Join(valid, ", "))
+}
+
+func matchFold(values []string, value string) (string, bool) {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return v, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,111 @@
+package ranges
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+// rangeIDRouteRejection is what the bundled API answers for /api/v1/ranges/regions: the path matches
+// its /{range_id} route and fails integer validation.
+const rangeIDRouteRejection = `{"detail":[{"loc":["path","range_id"],"msg":"Input should be a valid integer","type":"int_parsing"}]}`
+
+func testBlueprint(provider string, specs ...string) *client.BlueprintRange {
+	var hosts []client.BlueprintHost
+	for i, spec := range specs {
+		var host client.BlueprintHost
+		host.Hostname = "host" + string(rune('a'+i))
+		host.Spec = spec
+		hosts = append(hosts, host)
+	}
+
+	blueprint := &client.BlueprintRange{}
+	blueprint.Provider = provider
+	blueprint.VPCs = []client.BlueprintVPC{{Subnets: []client.BlueprintSubnet{{Hosts: hosts}}}}
+	return blueprint
+}
+
+func TestValidateDeployRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		blueprint  *client.BlueprintRange
+		region     string
+		wantRegion string
+		wantErr    string
+	}{
+		{name: "aws default region", blueprint: testBlueprint("aws", "tiny"), wantRegion: "us_east_1"},
+		{name: "aws region matched case-insensitively", blueprint: testBlueprint("aws", "huge"), region: "US_EAST_2", wantRegion: "us_east_2"},
+		{name: "aws unknown region", blueprint: testBlueprint("aws", "tiny"), region: "eu_west_9", wantErr: "invalid region 'eu_west_9' for provider aws (valid: us_east_1, us_east_2)"},
+		{name: "aws unknown spec", blueprint: testBlueprint("aws", "tiny", "giant"), region: "us_east_1", wantErr: "AWS cannot run the spec of these hosts: hostb (giant)"},
+		{name: "azure default region", blueprint: testBlueprint("azure", "medium"), wantRegion: "us_east_1"},
+		{name: "azure unknown region", blueprint: testBlueprint("azure", "small"), region: "westeurope", wantErr: "invalid region 'westeurope' for provider azure"},
+		{name: "azure unknown spec", blueprint: testBlueprint("azure", "giant"), wantErr: "Azure cannot run the spec of these hosts: hosta (giant)"},
+		{name: "provider without rules is left to the server", blueprint: testBlueprint("gcp", "giant"), region: "anything", wantRegion: "anything"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				testutil.RespondJSON(w, http.StatusUnprocessableEntity, rangeIDRouteRejection)
+			})
+
+			request := &client.DeployRangeRequest{Name: "lab", Region: tt.region}
+			err := validateDeployRequest(apiClient, request, tt.blueprint)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validateDeployRequest() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateDeployRequest() error = %v", err)
+			}
+			if request.Region != tt.wantRegion {
+				t.Errorf("region = %q, want %q", request.Region, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestGetValidRegionsFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []string
+		wantErr error
+	}{
+		{name: "route collision", status: http.StatusUnprocessableEntity, body: rangeIDRouteRejection, want: []string{"us_east_1", "us_east_2"}},
+		{name: "no endpoint", status: http.StatusNotFound, body: `{"detail":"Not Found"}`, want: []string{"us_east_1", "us_east_2"}},
+		{name: "server error", status: http.StatusInternalServerError, body: `{"detail":"boom"}`, want: []string{"us_east_1", "us_east_2"}},
+		{name: "server list", status: http.StatusOK, body: `["us_west_1"]`, want: []string{"us_west_1"}},
+		{name: "expired session", status: http.StatusUnauthorized, body: `{"detail":"Could not validate credentials"}`, wantErr: client.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				testutil.RespondJSON(w, tt.status, tt.body)
+			})
+
+			regions, err := getValidRegions(apiClient, "aws")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("getValidRegions() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getValidRegions() error = %v", err)
+			}
+			if strings.Join(regions, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("regions = %v, want %v", regions, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/concurrency"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+func newJobsPruneCommand() *cobra.Command {
+	var (
+		olderThan string
+		statuses  string
+		force     bool
+		workers   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete old finished job records",
+		Long:  "Delete range job records that finished before a cutoff, keeping recent and running jobs.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobsPrune(olderThan, statuses, force, workers)
+		},
+	}
+
+	cmd.Flags().StringVar(&olderThan, "older-than", "7d", "only prune jobs older than this (e.g. 12h, 7d)")
+	cmd.Flags().StringVarP(&statuses, "status", "s", "complete,failed", "comma-separated job statuses to prune")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "skip confirmation prompt")
+	cmd.Flags().IntVar(&workers, "concurrency", concurrency.DefaultLimit, "maximum number of job records to delete at once")
+
+	return cmd
+}
+
+func runJobsPrune(olderThan, statuses string, force bool, workers int) error {
+	if err := concurrency.ValidateLimit(workers); err != nil {
+		return err
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	age, err := utils.ParseDuration(olderThan)
+	if err != nil {
+		return err
+	}
+
+	statusSet := make(map[string]bool)
+	for _, status := range strings.Split(statuses, ",") {
+		status = strings.TrimSpace(status)
+		if status == "queued" || status == "in_progress" {
+			return fmt.Errorf("cannot prune %s jobs; use 'openlabs range jobs cancel' instead", status)
+		}
+		if status != "" {
+			statusSet[status] = true
+		}
+	}
+
+	jobs, err := apiClient.ListJobs("")
+	if err != nil {
+		return err
+	}
+
+	candidates := filterJobsForPrune(jobs, statusSet, time.Now().Add(-age))
+	if len(candidates) == 0 {
+		progress.ShowInfo("No jobs to prune")
+		return nil
+	}
+
+	if !force {
+		confirmed, err := utils.PromptConfirm(fmt.Sprintf("Delete %d job records?", len(candidates)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			progress.ShowInfo("Prune cancelled")
+			return nil
+		}
+	}
+
+	failures := make([]string, len(candidates))
+	err = concurrency.Run(len(candidates), workers, func(i int) error {
+		err := apiClient.DeleteJob(candidates[i].ARQJobID)
+		if err != nil {
+			failures[i] = fmt.Sprintf("%s: %v", candidates[i].ARQJobID, err)
+		}
+		return err
+	})
+
+	if errors.Is(err, client.ErrNotSupported) {
+		return fmt.Errorf("deleting job records is not supported by this server")
+	}
+
+	failures = slices.DeleteFunc(failures, func(failure string) bool { return failure == "" })
+	deleted := len(candidates) - len(failures)
+
+	if len(failures) > 0 {
+		progress.ShowWarning(fmt.Sprintf("Pruned %d of %d jobs; %d failed:", deleted, len(candidates), len(failures)))
+		for _, failure := range failures {
+			fmt.Printf("  %s\n", failure)
+		}
+		return fmt.Errorf("failed to prune %d jobs", len(failures))
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Pruned %d jobs", deleted))
+	return nil
+}
+
+// filterJobsForPrune selects range jobs with a matching status that finished (or, lacking a finish
+// time, were queued) before cutoff.
+func filterJobsForPrune(jobs []client.Job, statuses map[string]bool, cutoff time.Time) []client.Job {
+	var matches []client.Job
+
+	for _, job := range jobs {
+		if !isRangeJob(job.JobName) || !statuses[job.Status] {
+			continue
+		}
+
+		finished := job.EnqueueTime
+		if job.FinishTime != nil {
+			finished = *job.FinishTime
+		}
+
+		if finished.Before(cutoff) {
+			matches = append(matches, job)
+		}
+	}
+
+	return matches
+}
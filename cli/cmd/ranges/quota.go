@@ -0,0 +1,44 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+// checkDeployQuota fails before submission when deploying the blueprint would exceed the account's
+// range or host limits. Servers that don't report quotas skip the check.
+func checkDeployQuota(apiClient *client.Client, blueprint *client.BlueprintRange) error {
+	quota, err := apiClient.GetQuota()
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			logger.Debug("Server does not report quotas, skipping quota check")
+			return nil
+		}
+		logger.Warn("Skipping quota check: %v", err)
+		return nil
+	}
+
+	if quota.MaxRanges != nil && quota.UsedRanges >= *quota.MaxRanges {
+		return fmt.Errorf("range quota reached (%d of %d ranges in use); destroy a range before deploying", quota.UsedRanges, *quota.MaxRanges)
+	}
+
+	hosts := countBlueprintHosts(blueprint)
+	if quota.MaxHosts != nil && quota.UsedHosts+hosts > *quota.MaxHosts {
+		return fmt.Errorf("blueprint needs %d hosts but only %d of %d remain in your quota", hosts, *quota.MaxHosts-quota.UsedHosts, *quota.MaxHosts)
+	}
+
+	return nil
+}
+
+func countBlueprintHosts(blueprint *client.BlueprintRange) int {
+	total := 0
+	for _, vpc := range blueprint.VPCs {
+		for _, subnet := range vpc.Subnets {
+			total += len(subnet.Hosts)
+		}
+	}
+	return total
+}
@@ -11,11 +11,24 @@ func NewRangeCommand() *cobra.Command {
 		Long:  "Deploy, monitor, and manage cyber range infrastructure.",
 	}
 
+	cmd.PersistentFlags().BoolVar(&selectMode, "select", false, "choose the range (or, for deploy, the blueprint) from a menu")
+
 	cmd.AddCommand(newListCommand())
 	cmd.AddCommand(newStatusCommand())
+	cmd.AddCommand(newWatchCommand())
+	cmd.AddCommand(newDescribeCommand())
+	cmd.AddCommand(newStateCommand())
 	cmd.AddCommand(newDeployCommand())
 	cmd.AddCommand(newDestroyCommand())
+	cmd.AddCommand(newLabelCommand())
+	cmd.AddCommand(newPowerCommand())
+	cmd.AddCommand(newScaleCommand())
+	cmd.AddCommand(newRefreshCommand())
 	cmd.AddCommand(newKeyCommand())
+	cmd.AddCommand(newConnectCommand())
+	cmd.AddCommand(newVPNCommand())
+	cmd.AddCommand(newVNCCommand())
+	cmd.AddCommand(newCheckSSHCommand())
 	cmd.AddCommand(newJobsCommand())
 
 	return cmd
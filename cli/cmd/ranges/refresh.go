@@ -0,0 +1,90 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+func newRefreshCommand() *cobra.Command {
+	var (
+		wait    bool
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "refresh [range-id]",
+		Short: "Re-sync a range's state with its cloud resources",
+		Long:  "Ask the server to re-read a range's cloud resources and update its stored state, for ranges whose state looks wrong. Returns immediately with job ID unless --wait is given.",
+		Example: `  # Refresh range 12 and show its state once the refresh finishes
+  openlabs range refresh 12 --wait
+
+  # Refresh, then watch until the range settles
+  openlabs range refresh 12 && openlabs range status 12 --watch`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rangeID string
+			if len(args) > 0 {
+				rangeID = args[0]
+			}
+			return runRefresh(rangeID, wait, timeout)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&wait, "wait", "w", false, "wait for the refresh job to finish and show the updated status")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "maximum time to wait when using --wait")
+
+	return cmd
+}
+
+func runRefresh(rangeIDStr string, wait bool, timeout time.Duration) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeIDOrLast(apiClient, rangeIDStr)
+	if err != nil {
+		return err
+	}
+
+	jobResponse, err := apiClient.RefreshRange(rangeID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return fmt.Errorf("range refresh is not supported by this server")
+		}
+		return err
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Refresh started (Job ID: %s)", jobResponse.ARQJobID))
+	showJobURL(jobResponse.ARQJobID)
+
+	if !wait {
+		progress.ShowInfo("Use 'openlabs range status' to see the refreshed state")
+		return nil
+	}
+
+	tracker := progress.NewJobTracker(apiClient)
+	if _, err := tracker.TrackJob(jobResponse.ARQJobID, "Refreshing range state...", timeout); err != nil {
+		return fmt.Errorf("refresh did not complete: %w", err)
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	if output.IsTable(globalConfig.Format) {
+		displayRangeStatus(rangeData)
+		return output.WriteAlso(rangeData)
+	}
+
+	return output.Display(rangeData, globalConfig.Format)
+}
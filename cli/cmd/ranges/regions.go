@@ -0,0 +1,143 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+const defaultRegion = "us_east_1"
+
+// fallbackRegions is the built-in region list for providers without deploy rules.
+var fallbackRegions = []string{"us_east_1", "us_east_2"}
+
+var regionCache = map[string][]string{}
+
+func getValidRegions(apiClient *client.Client, provider string) ([]string, error) {
+	if regions, ok := regionCache[provider]; ok {
+		return regions, nil
+	}
+
+	regions, err := apiClient.ListRegions(provider)
+	if err != nil {
+		// A rejected session is worth reporting; any other failure only costs the server's list,
+		// and deploys should not depend on an optional endpoint
+		if errors.Is(err, client.ErrUnauthorized) || errors.Is(err, client.ErrForbidden) {
+			return nil, err
+		}
+		logger.Debug("Cannot get regions from the server, using built-in list: %v", err)
+		regions = fallbackRegionsFor(provider)
+	}
+
+	regionCache[provider] = regions
+	return regions, nil
+}
+
+// resolveRegion validates an explicit region or, when none was given, asks the user to pick one
+// for the provider. Non-interactive sessions fall back to the default region.
+func resolveRegion(apiClient *client.Client, provider, region string) (string, error) {
+	if region == "" && !utils.IsInteractive() {
+		return defaultRegionFor(provider), nil
+	}
+
+	regions, err := getValidRegions(apiClient, provider)
+	if err != nil {
+		return "", err
+	}
+
+	if region == "" {
+		return utils.SelectFromList(fmt.Sprintf("Select %s region", provider), regions)
+	}
+
+	if valid, ok := matchFold(regions, region); ok {
+		return valid, nil
+	}
+
+	return "", fmt.Errorf("invalid region '%s' for provider %s (valid: %s)", region, provider, strings.Join(regions, ", "))
+}
+
+// regionCompletionTimeout bounds the API calls made while completing --region, so a slow or
+// unreachable server can't stall the shell.
+const regionCompletionTimeout = 2 * time.Second
+
+// completeRegions suggests regions for --region. When the blueprint argument resolves, only its
+// provider's regions are offered. It returns no suggestions when unauthenticated, offline, or slow.
+func completeRegions(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg := globalConfig
+	if cfg == nil {
+		loaded, err := config.Load()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		cfg = loaded
+	}
+
+	if cfg.Token() == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completionConfig := *cfg
+	completionConfig.RequestTimeout = regionCompletionTimeout
+	apiClient := client.New(&completionConfig)
+
+	found := make(chan []string, 1)
+	go func() {
+		found <- regionSuggestions(apiClient, args)
+	}()
+
+	var regions []string
+	select {
+	case regions = <-found:
+	case <-time.After(regionCompletionTimeout):
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var suggestions []string
+	for _, region := range regions {
+		if strings.HasPrefix(region, toComplete) {
+			suggestions = append(suggestions, region)
+		}
+	}
+
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+func regionSuggestions(apiClient *client.Client, args []string) []string {
+	var providers []string
+	for _, p := range deployProviders {
+		providers = append(providers, p.name)
+	}
+
+	if len(args) > 0 {
+		if blueprintID, err := resolveBlueprintReference(apiClient, args[0]); err == nil {
+			if blueprint, err := apiClient.GetBlueprintRange(blueprintID); err == nil {
+				providers = []string{blueprint.Provider}
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var regions []string
+	for _, provider := range providers {
+		providerRegions, err := getValidRegions(apiClient, provider)
+		if err != nil {
+			return nil
+		}
+		for _, region := range providerRegions {
+			if !seen[region] {
+				seen[region] = true
+				regions = append(regions, region)
+			}
+		}
+	}
+
+	return regions
+}
@@ -0,0 +1,137 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+type scaleOptions struct {
+	size    int
+	wait    bool
+	timeout time.Duration
+}
+
+func newScaleCommand() *cobra.Command {
+	var opts scaleOptions
+
+	cmd := &cobra.Command{
+		Use:   "scale [range-id] <host>",
+		Short: "Change the disk size of a host in a range",
+		Long:  "Resize one host of a deployed range without redeploying it. The host is given by hostname or host ID, and the new size is checked against the sizes the server offers for the range's provider. The change runs as a job; use --wait to wait for it. When only the host is given, the range defaults to the one saved by 'range deploy --remember'.",
+		Example: `  # Give the web server in range 12 a 16 GB disk
+  openlabs range scale 12 web-01 --size 16
+
+  # Resize and wait for the job to finish
+  openlabs range scale 12 web-01 --size 32 --wait`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rangeRef string
+			if len(args) == 2 {
+				rangeRef = args[0]
+			}
+			return runScale(rangeRef, args[len(args)-1], opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.size, "size", 0, "new disk size in GB")
+	cmd.Flags().BoolVarP(&opts.wait, "wait", "w", false, "wait for the resize to finish")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 15*time.Minute, "maximum time to wait when using --wait")
+
+	_ = cmd.MarkFlagRequired("size")
+
+	return cmd
+}
+
+func runScale(rangeRef, hostRef string, opts scaleOptions) error {
+	if opts.size <= 0 {
+		return fmt.Errorf("--size must be a positive number of GB")
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeIDOrLast(apiClient, rangeRef)
+	if err != nil {
+		return err
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	host, err := findHost(rangeData, hostRef)
+	if err != nil {
+		return err
+	}
+
+	if host.Size == opts.size {
+		progress.ShowInfo(fmt.Sprintf("Host %s already has a %d GB disk", host.Hostname, opts.size))
+		return nil
+	}
+
+	if err := checkHostSize(apiClient, rangeData.Provider, opts.size); err != nil {
+		return err
+	}
+
+	response, err := apiClient.ScaleHost(rangeID, host.ID, opts.size)
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return fmt.Errorf("scaling hosts is not supported by this server; redeploy the range with a changed blueprint instead")
+		}
+		return err
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Resize of host %s from %d GB to %d GB started (Job ID: %s)", host.Hostname, host.Size, opts.size, response.ARQJobID))
+	showJobURL(response.ARQJobID)
+
+	if !opts.wait {
+		if !output.IsTable(globalConfig.Format) {
+			return output.Display(response, globalConfig.Format)
+		}
+		progress.ShowInfo(fmt.Sprintf("Use 'openlabs range jobs show %s' to check progress", response.ARQJobID))
+		return output.WriteAlso(response)
+	}
+
+	tracker := progress.NewJobTracker(apiClient)
+	if _, err := tracker.TrackJob(response.ARQJobID, fmt.Sprintf("Resizing %s...", host.Hostname), opts.timeout); err != nil {
+		return fmt.Errorf("resize of host %s did not complete: %w", host.Hostname, err)
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("Host %s now has a %d GB disk", host.Hostname, opts.size))
+	return nil
+}
+
+// checkHostSize rejects a size the server does not offer for provider. Servers that do not publish
+// their host options are left to validate the size themselves.
+func checkHostSize(apiClient *client.Client, provider string, size int) error {
+	options, err := apiClient.GetHostOptions(provider)
+	if err != nil {
+		logger.Debug("Skipping host size check: %v", err)
+		return nil
+	}
+
+	if len(options.Sizes) == 0 || slices.Contains(options.Sizes, size) {
+		return nil
+	}
+
+	sizes := make([]string, len(options.Sizes))
+	for i, s := range options.Sizes {
+		sizes[i] = strconv.Itoa(s)
+	}
+	return fmt.Errorf("invalid size: %d GB (valid for %s: %s)", size, provider, strings.Join(sizes, ", "))
+}
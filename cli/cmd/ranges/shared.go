@@ -7,10 +7,16 @@ import (
 
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
 var globalConfig *config.Config
 
+// selectMode is set by --select: commands pick their range or blueprint from a menu instead of
+// taking it as an argument.
+var selectMode bool
+
 func SetGlobalConfig(cfg *config.Config) {
 	globalConfig = cfg
 }
@@ -24,6 +30,10 @@ func getClient() *client.Client {
 }
 
 func resolveRangeID(apiClient *client.Client, idStr string) (int, error) {
+	if selectMode && idStr != "" {
+		return 0, fmt.Errorf("--select cannot be combined with a range ID or name")
+	}
+
 	if idStr == "" {
 		ranges, err := apiClient.ListRanges()
 		if err != nil {
@@ -34,11 +44,15 @@ func resolveRangeID(apiClient *client.Client, idStr string) (int, error) {
 			return 0, fmt.Errorf("no ranges found")
 		}
 
-		if len(ranges) == 1 {
+		if len(ranges) == 1 && !selectMode {
 			return ranges[0].ID, nil
 		}
 
-		return 0, fmt.Errorf("multiple ranges found, please specify range ID")
+		if !selectMode && !utils.IsInteractive() {
+			return 0, fmt.Errorf("multiple ranges found, please specify range ID")
+		}
+
+		return selectRange(ranges)
 	}
 
 	if id, err := strconv.Atoi(idStr); err == nil {
@@ -53,14 +67,16 @@ func resolveRangeID(apiClient *client.Client, idStr string) (int, error) {
 	var matches []client.DeployedRangeHeader
 	nameLower := strings.ToLower(idStr)
 
-	for _, r := range ranges {
+	names := make([]string, len(ranges))
+	for i, r := range ranges {
+		names[i] = r.Name
 		if strings.ToLower(r.Name) == nameLower {
 			matches = append(matches, r)
 		}
 	}
 
 	if len(matches) == 0 {
-		return 0, fmt.Errorf("no range found with name '%s'", idStr)
+		return 0, fmt.Errorf("no range found with name '%s'%s", idStr, utils.DidYouMean(idStr, names))
 	}
 
 	if len(matches) > 1 {
@@ -69,3 +85,75 @@ func resolveRangeID(apiClient *client.Client, idStr string) (int, error) {
 
 	return matches[0].ID, nil
 }
+
+// selectRange asks the user to pick one of ranges from a numbered menu.
+func selectRange(ranges []client.DeployedRangeHeader) (int, error) {
+	options := make([]string, len(ranges))
+	for i, r := range ranges {
+		options[i] = fmt.Sprintf("%s (ID: %d, %s)", r.Name, r.ID, r.State)
+	}
+
+	index, err := utils.SelectIndex("Select a range", options)
+	if err != nil {
+		return 0, err
+	}
+	return ranges[index].ID, nil
+}
+
+// findHost looks up a host in a range by ID or by hostname, ignoring case.
+func findHost(rangeData *client.DeployedRange, ref string) (*client.DeployedHost, error) {
+	id, idErr := strconv.Atoi(ref)
+
+	var hostnames []string
+	for _, vpc := range rangeData.VPCs {
+		for _, subnet := range vpc.Subnets {
+			for i, host := range subnet.Hosts {
+				if (idErr == nil && host.ID == id) || strings.EqualFold(host.Hostname, ref) {
+					return &subnet.Hosts[i], nil
+				}
+				hostnames = append(hostnames, host.Hostname)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no host '%s' in range %d%s", ref, rangeData.ID, utils.DidYouMean(ref, hostnames))
+}
+
+// rememberRange records rangeID as the last deployed range. Failing to save only loses the default,
+// so it is reported as a warning.
+func rememberRange(rangeID int) {
+	state, err := config.LoadState()
+	if err == nil {
+		state.LastRangeID = rangeID
+		err = state.Save()
+	}
+	if err != nil {
+		progress.ShowWarning(fmt.Sprintf("Could not remember range %d: %v", rangeID, err))
+	}
+}
+
+// resolveRangeIDOrLast works like resolveRangeID, except that with no ID given it prefers the range
+// saved by 'range deploy --remember' while that range still exists.
+func resolveRangeIDOrLast(apiClient *client.Client, idStr string) (int, error) {
+	if idStr != "" || selectMode {
+		return resolveRangeID(apiClient, idStr)
+	}
+
+	state, err := config.LoadState()
+	if err != nil || state.LastRangeID == 0 {
+		return resolveRangeID(apiClient, idStr)
+	}
+
+	ranges, err := apiClient.ListRanges()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list ranges: %w", err)
+	}
+
+	for _, r := range ranges {
+		if r.ID == state.LastRangeID {
+			return r.ID, nil
+		}
+	}
+
+	return resolveRangeID(apiClient, idStr)
+}
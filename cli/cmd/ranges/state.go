@@ -0,0 +1,318 @@
+package ranges
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+// StateSummary is a readable digest of a range's Terraform state.
+type StateSummary struct {
+	RangeID          int               `json:"range_id"`
+	RangeName        string            `json:"range_name"`
+	TerraformVersion string            `json:"terraform_version,omitempty"`
+	Providers        []string          `json:"providers"`
+	Resources        int               `json:"resources"`
+	ResourceTypes    map[string]int    `json:"resource_types"`
+	Addresses        []StateAddress    `json:"addresses"`
+	Outputs          map[string]string `json:"outputs,omitempty"`
+}
+
+// StateAddress is a resource instance that has an IP address, with its ID.
+type StateAddress struct {
+	Resource  string `json:"resource"`
+	ID        string `json:"id,omitempty"`
+	PublicIP  string `json:"public_ip,omitempty"`
+	PrivateIP string `json:"private_ip,omitempty"`
+}
+
+func newStateCommand() *cobra.Command {
+	var raw bool
+
+	cmd := &cobra.Command{
+		Use:   "state [range-id]",
+		Short: "Summarize a range's Terraform state",
+		Long:  "Show what is actually deployed for a range according to its Terraform state: providers, resource counts by type, resource IDs and IP addresses, and outputs. Sensitive outputs are hidden from the summary. Use --raw for the full state as JSON, sensitive values included.",
+		Example: `  openlabs range state 42
+  openlabs range state 42 --raw > state.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rangeID string
+			if len(args) > 0 {
+				rangeID = args[0]
+			}
+			return runState(rangeID, raw)
+		},
+	}
+
+	cmd.Flags().BoolVar(&raw, "raw", false, "print the full state file as JSON")
+
+	return cmd
+}
+
+func runState(rangeIDStr string, raw bool) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeID(apiClient, rangeIDStr)
+	if err != nil {
+		return err
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	state, err := decodeStateFile(rangeData.StateFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the state of range %d: %w", rangeID, err)
+	}
+
+	if state == nil {
+		progress.ShowInfo(fmt.Sprintf("Range %d has no state file; it may still be deploying, or the server does not return state", rangeID))
+		return nil
+	}
+
+	if raw {
+		data, err := json.MarshalIndent(state, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	summary := summarizeState(state)
+	summary.RangeID = rangeData.ID
+	summary.RangeName = rangeData.Name
+
+	if output.IsTable(globalConfig.Format) {
+		if err := displayStateSummary(summary); err != nil {
+			return err
+		}
+		return output.WriteAlso(summary)
+	}
+
+	return output.Display(summary, globalConfig.Format)
+}
+
+// decodeStateFile returns the state as a JSON object. Servers send it either as an object or as a
+// JSON-encoded string; nil is returned when there is no state.
+func decodeStateFile(stateFile interface{}) (map[string]interface{}, error) {
+	switch state := stateFile.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		if len(state) == 0 {
+			return nil, nil
+		}
+		return state, nil
+	case string:
+		if strings.TrimSpace(state) == "" {
+			return nil, nil
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(state), &decoded); err != nil {
+			return nil, fmt.Errorf("state file is not valid JSON: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unexpected state file of type %T", stateFile)
+	}
+}
+
+// summarizeState extracts the commonly useful parts of a Terraform state. Fields that are missing
+// or shaped differently than expected are skipped rather than treated as errors.
+func summarizeState(state map[string]interface{}) *StateSummary {
+	summary := &StateSummary{
+		TerraformVersion: stringField(state, "terraform_version"),
+		ResourceTypes:    map[string]int{},
+		Addresses:        []StateAddress{},
+	}
+
+	providers := map[string]bool{}
+	resources, _ := state["resources"].([]interface{})
+	for _, item := range resources {
+		resource, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		resourceType := stringField(resource, "type")
+		if provider := providerName(stringField(resource, "provider")); provider != "" {
+			providers[provider] = true
+		}
+
+		instances, _ := resource["instances"].([]interface{})
+		if len(instances) == 0 {
+			instances = []interface{}{map[string]interface{}{}}
+		}
+
+		for _, inst := range instances {
+			instance, _ := inst.(map[string]interface{})
+			if stringField(resource, "mode") != "data" {
+				summary.Resources++
+				summary.ResourceTypes[resourceType]++
+			}
+
+			attributes, _ := instance["attributes"].(map[string]interface{})
+			address := StateAddress{
+				Resource:  resourceAddress(resource, instance),
+				ID:        stringField(attributes, "id"),
+				PublicIP:  firstStringField(attributes, "public_ip", "public_ip_address", "ip_address"),
+				PrivateIP: firstStringField(attributes, "private_ip", "private_ip_address"),
+			}
+			if address.PublicIP != "" || address.PrivateIP != "" {
+				summary.Addresses = append(summary.Addresses, address)
+			}
+		}
+	}
+
+	for provider := range providers {
+		summary.Providers = append(summary.Providers, provider)
+	}
+	sort.Strings(summary.Providers)
+
+	if outputs, ok := state["outputs"].(map[string]interface{}); ok && len(outputs) > 0 {
+		summary.Outputs = map[string]string{}
+		for name, item := range outputs {
+			summary.Outputs[name] = outputValue(item)
+		}
+	}
+
+	return summary
+}
+
+// providerName turns a provider reference such as provider["registry.terraform.io/hashicorp/aws"],
+// or the older provider.aws, into its short name, aws.
+func providerName(ref string) string {
+	ref = strings.TrimPrefix(ref, "provider")
+	ref = strings.Trim(ref, `.[]"`)
+	if i := strings.LastIndex(ref, "/"); i >= 0 {
+		ref = ref[i+1:]
+	}
+	if i := strings.Index(ref, "."); i >= 0 {
+		ref = ref[:i]
+	}
+	return ref
+}
+
+// resourceAddress builds a Terraform-style address, e.g. module.vpc.aws_instance.web[0].
+func resourceAddress(resource, instance map[string]interface{}) string {
+	address := stringField(resource, "type") + "." + stringField(resource, "name")
+	if stringField(resource, "mode") == "data" {
+		address = "data." + address
+	}
+	if module := stringField(resource, "module"); module != "" {
+		address = module + "." + address
+	}
+
+	switch key := instance["index_key"].(type) {
+	case string:
+		address += fmt.Sprintf("[%q]", key)
+	case float64:
+		address += fmt.Sprintf("[%d]", int(key))
+	}
+
+	return address
+}
+
+// outputValue renders a Terraform output for display, hiding values marked sensitive.
+func outputValue(item interface{}) string {
+	out, ok := item.(map[string]interface{})
+	if !ok {
+		return fmt.Sprint(item)
+	}
+
+	if sensitive, _ := out["sensitive"].(bool); sensitive {
+		return "(sensitive)"
+	}
+
+	switch value := out["value"].(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(data)
+	}
+}
+
+func stringField(fields map[string]interface{}, key string) string {
+	switch value := fields[key].(type) {
+	case string:
+		return value
+	case float64:
+		return fmt.Sprintf("%v", value)
+	default:
+		return ""
+	}
+}
+
+func firstStringField(fields map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value := stringField(fields, key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func displayStateSummary(s *StateSummary) error {
+	fmt.Printf("Range:     %s (ID: %d)\n", s.RangeName, s.RangeID)
+	if s.TerraformVersion != "" {
+		fmt.Printf("Terraform: %s\n", s.TerraformVersion)
+	}
+	fmt.Printf("Providers: %s\n", strings.Join(s.Providers, ", "))
+	fmt.Printf("Resources: %d\n", s.Resources)
+
+	types := make([]string, 0, len(s.ResourceTypes))
+	width := 0
+	for resourceType := range s.ResourceTypes {
+		types = append(types, resourceType)
+		width = max(width, len(resourceType))
+	}
+	sort.Strings(types)
+	for _, resourceType := range types {
+		fmt.Printf("  %-*s  %d\n", width, resourceType, s.ResourceTypes[resourceType])
+	}
+
+	if len(s.Addresses) > 0 {
+		fmt.Println()
+		fmt.Println("Addresses:")
+		table, err := output.NewFormatter("table").Format(s.Addresses)
+		if err != nil {
+			return err
+		}
+		fmt.Print(table)
+	}
+
+	if len(s.Outputs) > 0 {
+		fmt.Println()
+		fmt.Println("Outputs:")
+		names := make([]string, 0, len(s.Outputs))
+		for name := range s.Outputs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("  %s = %s\n", name, s.Outputs[name])
+		}
+	}
+
+	return nil
+}
@@ -2,44 +2,183 @@ package ranges
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/concurrency"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
+// RangeStatusRow is one range in the combined table of a multi-range status.
+type RangeStatusRow struct {
+	ID      int        `json:"id,omitempty"`
+	Name    string     `json:"name"`
+	State   string     `json:"state,omitempty"`
+	Region  string     `json:"region,omitempty"`
+	Hosts   int        `json:"hosts"`
+	Created *time.Time `json:"created,omitempty"`
+	Error   string     `json:"error,omitempty"`
+}
+
 func newStatusCommand() *cobra.Command {
-	return &cobra.Command{
-		Use:   "status [range-id]",
+	var (
+		watch   bool
+		timeout time.Duration
+		all     bool
+		workers int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status [range-id...]",
 		Short: "Show range status",
-		Long:  "Display concise status information about a deployed range.",
-		Args:  cobra.MaximumNArgs(1),
+		Long:  "Display concise status information about a deployed range. Without a range ID, the range saved by 'range deploy --remember' is shown if it still exists. With several range IDs or names, or --all, the ranges are fetched concurrently and shown in one table.",
+		Example: `  openlabs range status 42
+  openlabs range status 42 web-lab 57
+  openlabs range status --all`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if all || len(args) > 1 {
+				if watch {
+					return fmt.Errorf("--watch works with a single range")
+				}
+				return runBatchStatus(args, all, workers)
+			}
+
 			var rangeID string
 			if len(args) > 0 {
 				rangeID = args[0]
 			}
-			return runStatus(rangeID)
+			return runStatus(rangeID, watch, timeout)
 		},
 	}
+
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "wait until the range leaves a transitional state")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "maximum time to watch")
+	cmd.Flags().BoolVar(&all, "all", false, "show the status of every range")
+	cmd.Flags().IntVar(&workers, "concurrency", concurrency.DefaultLimit, "maximum number of ranges to fetch at once")
+
+	return cmd
 }
 
-func runStatus(rangeIDStr string) error {
+func runStatus(rangeIDStr string, watch bool, timeout time.Duration) error {
 	apiClient := getClient()
 
 	if !apiClient.IsAuthenticated() {
 		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
 	}
 
-	rangeID, err := resolveRangeID(apiClient, rangeIDStr)
+	rangeID, err := resolveRangeIDOrLast(apiClient, rangeIDStr)
 	if err != nil {
 		return err
 	}
 
-	rangeData, err := apiClient.GetRange(rangeID)
+	var rangeData *client.DeployedRange
+	if watch {
+		rangeData, err = waitForRangeState(apiClient, rangeID, "", timeout)
+	} else {
+		rangeData, err = apiClient.GetRange(rangeID)
+		if err != nil {
+			err = fmt.Errorf("failed to get range details: %w", err)
+		}
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get range details: %w", err)
+		return err
 	}
 
-	// Display concise status
+	displayRangeStatus(rangeData)
+	return output.WriteAlso(rangeData)
+}
+
+// runBatchStatus shows several ranges in one table. A range that cannot be resolved or fetched gets
+// a row with its error instead of stopping the others.
+func runBatchStatus(args []string, all bool, workers int) error {
+	if all && len(args) > 0 {
+		return fmt.Errorf("--all cannot be combined with range IDs or names")
+	}
+
+	if err := concurrency.ValidateLimit(workers); err != nil {
+		return err
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	if all {
+		ranges, err := apiClient.ListRanges()
+		if err != nil {
+			return fmt.Errorf("failed to list ranges: %w", err)
+		}
+		if len(ranges) == 0 {
+			progress.ShowInfo("No ranges found")
+			return nil
+		}
+		for _, r := range ranges {
+			args = append(args, strconv.Itoa(r.ID))
+		}
+	}
+
+	rows := make([]RangeStatusRow, len(args))
+
+	spinner := progress.NewSpinner(fmt.Sprintf("Fetching %d ranges...", len(args)))
+	spinner.Start()
+
+	err := concurrency.Run(len(args), workers, func(i int) error {
+		rows[i].Name = args[i]
+
+		rangeID, err := resolveRangeID(apiClient, args[i])
+		if err != nil {
+			rows[i].Error = utils.TruncateString(err.Error(), 60)
+			return err
+		}
+		rows[i].ID = rangeID
+
+		rangeData, err := apiClient.GetRange(rangeID)
+		if err != nil {
+			rows[i].Error = utils.TruncateString(err.Error(), 60)
+			return err
+		}
+
+		_, hosts := countProvisionedHosts(rangeData)
+		rows[i] = RangeStatusRow{
+			ID:     rangeData.ID,
+			Name:   rangeData.Name,
+			State:  rangeData.State,
+			Region: rangeData.Region,
+			Hosts:  hosts,
+		}
+		if !rangeData.Date.IsZero() {
+			rows[i].Created = &rangeData.Date
+		}
+		return nil
+	})
+
+	spinner.Stop()
+
+	if err := output.Display(rows, globalConfig.Format); err != nil {
+		return err
+	}
+
+	if err != nil {
+		failed := 0
+		for _, row := range rows {
+			if row.Error != "" {
+				failed++
+			}
+		}
+		return fmt.Errorf("%d of %d ranges could not be read", failed, len(rows))
+	}
+
+	return nil
+}
+
+func displayRangeStatus(rangeData *client.DeployedRange) {
 	fmt.Printf("Range: %s (ID: %d)\n", rangeData.Name, rangeData.ID)
 	fmt.Printf("State: %s\n", rangeData.State)
 	if rangeData.Description != "" {
@@ -56,7 +195,5 @@ func runStatus(rangeIDStr string) error {
 	}
 	fmt.Printf("Hosts: %d\n", totalHosts)
 
-	fmt.Printf("Created: %s\n", rangeData.Date.Format("2006-01-02 15:04:05"))
-
-	return nil
+	fmt.Printf("Created: %s\n", output.FormatTime(rangeData.Date))
 }
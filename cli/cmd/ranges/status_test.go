@@ -0,0 +1,47 @@
+package ranges
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestBatchStatusOmitsMissingDates(t *testing.T) {
+	newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/ranges/1":
+			testutil.RespondJSON(w, http.StatusOK, `{"id":1,"name":"web-lab","state":"on","date":"2026-03-04T05:06:07Z","vpcs":[]}`)
+		case "/api/v1/ranges/2":
+			testutil.RespondJSON(w, http.StatusOK, `{"id":2,"name":"ad-lab","state":"building","vpcs":[]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+	globalConfig.Format = "json"
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	err = runBatchStatus([]string{"1", "2"}, false, 2)
+	os.Stdout = stdout
+	w.Close()
+	if err != nil {
+		t.Fatalf("runBatchStatus() error = %v", err)
+	}
+
+	data, _ := io.ReadAll(r)
+	got := string(data)
+	if strings.Count(got, `"created"`) != 1 || !strings.Contains(got, "2026-03-04T05:06:07Z") {
+		t.Errorf("output has %d created dates, want only web-lab's:\n%s", strings.Count(got, `"created"`), got)
+	}
+	if strings.Contains(got, "0001-01-01") {
+		t.Errorf("output has a zero date for the range without one:\n%s", got)
+	}
+}
@@ -0,0 +1,125 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+type vncOptions struct {
+	open   bool
+	reveal bool
+}
+
+func newVNCCommand() *cobra.Command {
+	var opts vncOptions
+
+	cmd := &cobra.Command{
+		Use:   "vnc [range-id]",
+		Short: "Show the VNC console of a range",
+		Long:  "Print the VNC console URL and login of a range deployed with VNC. The password is masked unless --reveal is given, and --open opens the console in your browser.",
+		Example: `  # Show the VNC console of range 12
+  openlabs range vnc 12
+
+  # Show the password and open the console
+  openlabs range vnc 12 --reveal --open`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rangeID string
+			if len(args) > 0 {
+				rangeID = args[0]
+			}
+			return runVNC(rangeID, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.open, "open", false, "open the VNC console in the default browser")
+	cmd.Flags().BoolVar(&opts.reveal, "reveal", false, "show the VNC password instead of masking it")
+
+	return cmd
+}
+
+func runVNC(rangeIDStr string, opts vncOptions) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeIDOrLast(apiClient, rangeIDStr)
+	if err != nil {
+		return err
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	if !rangeData.VNC {
+		return fmt.Errorf("range %d (%s) was not deployed with VNC; connect over SSH with the key from 'openlabs range key %d'", rangeID, rangeData.Name, rangeID)
+	}
+
+	info, err := apiClient.GetRangeVNCInfo(rangeID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return fmt.Errorf("this server does not provide VNC access details")
+		}
+		return err
+	}
+
+	shown := info
+	if !opts.reveal {
+		shown = maskVNCPassword(info)
+	}
+
+	if !output.IsTable(globalConfig.Format) {
+		if err := output.Display(shown, globalConfig.Format); err != nil {
+			return err
+		}
+	} else {
+		displayVNCInfo(shown)
+		if err := output.WriteAlso(shown); err != nil {
+			return err
+		}
+	}
+
+	if opts.open {
+		return openVNCConsole(info)
+	}
+	return nil
+}
+
+// maskVNCPassword returns a copy of info with the password masked.
+func maskVNCPassword(info *client.VNCInfo) *client.VNCInfo {
+	masked := *info
+	masked.Password = utils.MaskSecret(info.Password)
+	return &masked
+}
+
+func displayVNCInfo(info *client.VNCInfo) {
+	fmt.Printf("VNC console: %s\n", info.URL)
+	if info.Username != "" {
+		fmt.Printf("Username:    %s\n", info.Username)
+	}
+	if info.Password != "" {
+		fmt.Printf("Password:    %s\n", info.Password)
+	}
+}
+
+func openVNCConsole(info *client.VNCInfo) error {
+	if info.URL == "" {
+		return fmt.Errorf("the server did not return a VNC console URL")
+	}
+	if err := utils.OpenBrowser(info.URL); err != nil {
+		return err
+	}
+	progress.ShowSuccess("Opened the VNC console in your browser")
+	return nil
+}
@@ -0,0 +1,177 @@
+package ranges
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+const (
+	vpnTypeOpenVPN   = "openvpn"
+	vpnTypeWireGuard = "wireguard"
+)
+
+// vpnConfigExtensions are the file extensions the VPN clients expect for each config type.
+var vpnConfigExtensions = map[string]string{
+	vpnTypeOpenVPN:   ".ovpn",
+	vpnTypeWireGuard: ".conf",
+}
+
+type VPNConfigResult struct {
+	RangeID int    `json:"range_id"`
+	Type    string `json:"type"`
+	Path    string `json:"path"`
+}
+
+func newVPNCommand() *cobra.Command {
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "vpn [range-id]",
+		Short: "Download the VPN config of a range",
+		Long:  "Save the client config for the VPN of a range deployed with VPN, readable only by you. OpenVPN configs are saved as .ovpn and WireGuard configs as .conf, by default next to the range keys. Use --output - to print the config instead.",
+		Example: `  # Save the VPN config of range 12 next to its SSH key
+  openlabs range vpn 12
+
+  # Save it to a chosen file
+  openlabs range vpn 12 --output range.ovpn
+
+  # Print it for another tool
+  openlabs range vpn 12 --output - | nmcli connection import type openvpn file /dev/stdin`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rangeID string
+			if len(args) > 0 {
+				rangeID = args[0]
+			}
+			return runVPN(rangeID, outputPath)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "file to save the config to, or - for stdout (default: range-<id>.ovpn or .conf in the key directory)")
+
+	return cmd
+}
+
+func runVPN(rangeIDStr, outputPath string) error {
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeIDOrLast(apiClient, rangeIDStr)
+	if err != nil {
+		return err
+	}
+
+	rangeData, err := apiClient.GetRange(rangeID)
+	if err != nil {
+		return fmt.Errorf("failed to get range details: %w", err)
+	}
+
+	if !rangeData.VPN {
+		return fmt.Errorf("range %d (%s) was not deployed with VPN; connect over SSH with the key from 'openlabs range key %d'", rangeID, rangeData.Name, rangeID)
+	}
+
+	vpnConfig, err := apiClient.GetRangeVPNConfig(rangeID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			return fmt.Errorf("this server does not provide VPN configs")
+		}
+		return err
+	}
+
+	vpnType := vpnConfigType(vpnConfig)
+
+	if outputPath == "-" {
+		fmt.Println(strings.TrimRight(vpnConfig.Config, "\n"))
+		return nil
+	}
+
+	path, err := saveVPNConfig(rangeID, vpnConfig.Config, vpnType, outputPath)
+	if err != nil {
+		return err
+	}
+
+	result := VPNConfigResult{RangeID: rangeID, Type: vpnType, Path: path}
+	if !output.IsTable(globalConfig.Format) {
+		return output.Display(result, globalConfig.Format)
+	}
+
+	progress.ShowSuccess(fmt.Sprintf("%s config saved to %s", vpnTypeName(vpnType), path))
+	progress.ShowInfo("Connect with: " + vpnConnectCommand(vpnType, path))
+	return output.WriteAlso(result)
+}
+
+// vpnConfigType returns the VPN a config is for, as reported by the server or, failing that, told
+// from its contents: WireGuard configs have [Interface] and [Peer] sections, anything else is
+// treated as OpenVPN.
+func vpnConfigType(vpnConfig *client.VPNConfig) string {
+	if vpnType := strings.ToLower(vpnConfig.Type); vpnConfigExtensions[vpnType] != "" {
+		return vpnType
+	}
+
+	if strings.Contains(vpnConfig.Config, "[Interface]") && strings.Contains(vpnConfig.Config, "[Peer]") {
+		return vpnTypeWireGuard
+	}
+	return vpnTypeOpenVPN
+}
+
+func vpnTypeName(vpnType string) string {
+	if vpnType == vpnTypeWireGuard {
+		return "WireGuard"
+	}
+	return "OpenVPN"
+}
+
+// saveVPNConfig writes a range's VPN config to path, readable only by the user, and returns the path
+// it used. An empty path means range-<id> in the key directory; a path without an extension gets the
+// one the VPN client expects.
+func saveVPNConfig(rangeID int, config, vpnType, path string) (string, error) {
+	ext := vpnConfigExtensions[vpnType]
+
+	if path == "" {
+		keyDir, err := keyDirectory()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(keyDir, fmt.Sprintf("range-%d", rangeID))
+	}
+
+	path = utils.ExpandPath(path)
+	switch filepath.Ext(path) {
+	case "":
+		path += ext
+	case ext:
+	default:
+		progress.ShowWarning(fmt.Sprintf("This is a %s config, which is usually saved as %s", vpnTypeName(vpnType), ext))
+	}
+
+	if err := os.WriteFile(path, []byte(strings.TrimRight(config, "\n")+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to save VPN config: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0600); err != nil {
+		return "", fmt.Errorf("failed to restrict VPN config permissions: %w", err)
+	}
+
+	return path, nil
+}
+
+// vpnConnectCommand returns the command that brings up the VPN in the config at path.
+func vpnConnectCommand(vpnType, path string) string {
+	if vpnType == vpnTypeWireGuard {
+		return "sudo wg-quick up " + path
+	}
+	return "sudo openvpn --config " + path
+}
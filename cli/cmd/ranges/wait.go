@@ -0,0 +1,84 @@
+package ranges
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+const rangeStatePollInterval = 5 * time.Second
+
+var knownRangeStates = map[string]bool{
+	"on":       true,
+	"off":      true,
+	"starting": true,
+	"stopping": true,
+}
+
+var transitionalRangeStates = map[string]bool{
+	"starting": true,
+	"stopping": true,
+}
+
+// normalizeRangeState maps user-facing aliases onto the states reported by the API.
+func normalizeRangeState(state string) (string, error) {
+	state = strings.ToLower(strings.TrimSpace(state))
+	if state == "ready" {
+		return "on", nil
+	}
+
+	if !knownRangeStates[state] {
+		return "", fmt.Errorf("invalid range state: %s (valid: ready, on, off, starting, stopping)", state)
+	}
+
+	return state, nil
+}
+
+// waitForRangeState polls a range until it reaches target. With an empty target it waits until the
+// range leaves any transitional state. It fails if the range settles in a different state, reports a
+// state the CLI does not recognise, or the timeout expires.
+func waitForRangeState(apiClient *client.Client, rangeID int, target string, timeout time.Duration) (*client.DeployedRange, error) {
+	spinner := progress.NewSpinner(fmt.Sprintf("Waiting for range %d...", rangeID))
+	spinner.Start()
+	defer spinner.Stop()
+
+	deadline := time.Now().Add(timeout)
+	lastState := ""
+
+	for {
+		rangeData, err := apiClient.GetRange(rangeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get range details: %w", err)
+		}
+
+		state := strings.ToLower(rangeData.State)
+		if state != lastState {
+			spinner.UpdateMessage(fmt.Sprintf("Range %d state: %s", rangeID, state))
+			lastState = state
+		}
+
+		if !knownRangeStates[state] {
+			return rangeData, fmt.Errorf("range %d reported unknown state: %s", rangeID, rangeData.State)
+		}
+
+		if target != "" && state == target {
+			return rangeData, nil
+		}
+
+		if !transitionalRangeStates[state] {
+			if target == "" {
+				return rangeData, nil
+			}
+			return rangeData, fmt.Errorf("range %d settled in state %s instead of %s", rangeID, state, target)
+		}
+
+		if time.Now().Add(rangeStatePollInterval).After(deadline) {
+			return rangeData, fmt.Errorf("timed out after %v waiting for range %d (last state: %s)", timeout, rangeID, state)
+		}
+
+		time.Sleep(rangeStatePollInterval)
+	}
+}
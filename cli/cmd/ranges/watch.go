@@ -0,0 +1,150 @@
+package ranges
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+func newWatchCommand() *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch [range-id]",
+		Short: "Watch a range until it settles",
+		Long:  "Poll a range and keep a short status block (state, provisioned hosts, jumpbox IP) up to date until the range leaves its transitional state (starting or stopping). On a terminal the block is redrawn in place; otherwise a line is printed whenever something changes. Press Ctrl-C to stop watching.",
+		Example: `  # Watch range 12 while it starts
+  openlabs range watch 12
+
+  # Poll every 2 seconds
+  openlabs range watch 12 --interval 2s`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rangeID string
+			if len(args) > 0 {
+				rangeID = args[0]
+			}
+			return runWatch(rangeID, interval)
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", rangeStatePollInterval, "time between status checks")
+
+	return cmd
+}
+
+func runWatch(rangeIDStr string, interval time.Duration) error {
+	if interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	apiClient := getClient()
+
+	if !apiClient.IsAuthenticated() {
+		return fmt.Errorf("not authenticated. Run 'openlabs auth login' first")
+	}
+
+	rangeID, err := resolveRangeIDOrLast(apiClient, rangeIDStr)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Structured output only gets the final state; the live block is for people
+	live := output.IsTable(globalConfig.Format)
+	view := &watchView{inPlace: live && utils.IsTerminalOutput()}
+
+	for {
+		rangeData, err := apiClient.GetRangeContext(ctx, rangeID)
+		if ctx.Err() != nil {
+			progress.ShowInfo(fmt.Sprintf("Stopped watching range %d", rangeID))
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get range details: %w", err)
+		}
+
+		if live {
+			view.render(rangeData)
+		}
+
+		state := strings.ToLower(rangeData.State)
+		if !knownRangeStates[state] {
+			return fmt.Errorf("range %d reported unknown state: %s", rangeID, rangeData.State)
+		}
+
+		if !transitionalRangeStates[state] {
+			if !live {
+				return output.Display(rangeData, globalConfig.Format)
+			}
+			progress.ShowSuccess(fmt.Sprintf("Range %d is %s", rangeID, state))
+			return output.WriteAlso(rangeData)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(interval):
+		}
+	}
+}
+
+// watchView draws the status block of a watched range. On a terminal it redraws the block in place;
+// otherwise it prints one line per change so logs stay readable.
+type watchView struct {
+	inPlace   bool
+	lines     int
+	lastLine  string
+	startedAt time.Time
+	lastState string
+}
+
+func (v *watchView) render(rangeData *client.DeployedRange) {
+	state := strings.ToLower(rangeData.State)
+	if state != v.lastState {
+		v.lastState = state
+		v.startedAt = time.Now()
+	}
+
+	ready, total := countProvisionedHosts(rangeData)
+	jumpbox := rangeData.JumpboxPublicIP
+	if jumpbox == "" {
+		jumpbox = "pending"
+	}
+
+	if !v.inPlace {
+		line := fmt.Sprintf("state: %s, hosts: %d/%d ready, jumpbox: %s", state, ready, total, jumpbox)
+		if line != v.lastLine {
+			fmt.Printf("%s %s\n", time.Now().Format("15:04:05"), line)
+			v.lastLine = line
+		}
+		return
+	}
+
+	block := []string{
+		fmt.Sprintf("Range:   %s (ID: %d)", rangeData.Name, rangeData.ID),
+		fmt.Sprintf("State:   %s (for %s)", state, time.Since(v.startedAt).Round(time.Second)),
+		fmt.Sprintf("Hosts:   %d/%d ready", ready, total),
+		fmt.Sprintf("Jumpbox: %s", jumpbox),
+		fmt.Sprintf("Updated: %s", time.Now().Format("15:04:05")),
+	}
+
+	if v.lines > 0 {
+		// Move back to the top of the previous block and clear it
+		fmt.Printf("\033[%dA\033[J", v.lines)
+	}
+	fmt.Println(strings.Join(block, "\n"))
+	v.lines = len(block)
+}
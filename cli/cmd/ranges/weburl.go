@@ -0,0 +1,12 @@
+package ranges
+
+import (
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+)
+
+// showJobURL points to the job's page in the web UI, if its address is known.
+func showJobURL(jobID string) {
+	if jobURL := globalConfig.JobURL(jobID); jobURL != "" {
+		progress.ShowInfo("Follow in the web UI: " + jobURL)
+	}
+}
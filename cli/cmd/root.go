@@ -1,27 +1,50 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/cmd/audit"
 	"github.com/OpenLabsHQ/OpenLabs/cli/cmd/auth"
 	"github.com/OpenLabsHQ/OpenLabs/cli/cmd/blueprints"
+	"github.com/OpenLabsHQ/OpenLabs/cli/cmd/cache"
 	"github.com/OpenLabsHQ/OpenLabs/cli/cmd/config"
 	"github.com/OpenLabsHQ/OpenLabs/cli/cmd/ranges"
+	internalCache "github.com/OpenLabsHQ/OpenLabs/cli/internal/cache"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/color"
 	internalConfig "github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/output"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/progress"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/telemetry"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
 )
 
 var (
 	globalConfig *internalConfig.Config
 	configPath   string
+	configDir    string
 	outputFormat string
 	apiURL       string
-	verbose      bool
+	timeFormat   string
+	noDiscovery  bool
+	strict404    bool
+	noCache      bool
+	clearCache   bool
+	reqTimeout   time.Duration
+	totals       bool
+	queryPath    string
+	colorMode    string
+	alsoJSON     string
+	alsoYAML     string
+	verbosity    int
+	logLevel     string
 	version      string = "dev" // Set by ldflags during build
 )
 
@@ -33,26 +56,103 @@ var rootCmd = &cobra.Command{
 	SilenceUsage:  false,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		internalConfig.SetConfigDir(configDir)
+
 		if err := initializeGlobalConfig(); err != nil {
 			return fmt.Errorf("failed to initialize configuration: %w", err)
 		}
 
-		applyGlobalFlags()
+		if err := applyGlobalFlags(cmd); err != nil {
+			return err
+		}
+
+		askTelemetryOptIn(cmd)
 		return nil
 	},
 }
 
+// telemetryFlushWait is how long the CLI waits at exit for a usage event that is still being sent.
+const telemetryFlushWait = 300 * time.Millisecond
+
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	saveSessionState()
+	recordUsage(cmd, err, time.Since(start))
+
+	if err != nil {
 		handleError(err, rootCmd)
+		telemetry.Wait(telemetryFlushWait)
 		os.Exit(1)
 	}
+	telemetry.Wait(telemetryFlushWait)
+}
+
+// saveSessionState saves session state the command's requests changed in memory, such as a new CSRF
+// token, once instead of after every request.
+func saveSessionState() {
+	if globalConfig == nil {
+		return
+	}
+	if err := globalConfig.SaveCSRFToken(); err != nil {
+		logger.Debug("Could not save CSRF token: %v", err)
+	}
+}
+
+// recordUsage sends the command's usage event if the user opted in. Commands that stopped before the
+// config was loaded, such as --help, are not recorded.
+func recordUsage(cmd *cobra.Command, err error, elapsed time.Duration) {
+	if globalConfig == nil || cmd == nil {
+		return
+	}
+
+	telemetry.Send(globalConfig, telemetry.Event{
+		Command:    strings.Join(commandPath(cmd), " "),
+		Success:    err == nil,
+		DurationMS: elapsed.Milliseconds(),
+		Version:    getVersion(),
+	})
+}
+
+// askTelemetryOptIn asks on the first interactive run whether anonymous usage data may be sent, and
+// saves the answer so the question is not repeated. Scripts, piped output, and config commands are
+// never interrupted, and telemetry stays off until the user says yes.
+func askTelemetryOptIn(cmd *cobra.Command) {
+	if globalConfig.TelemetryEnabled != nil || globalConfig.ReadOnly() {
+		return
+	}
+	if !utils.IsInteractive() || !utils.IsTerminalOutput() {
+		return
+	}
+
+	path := commandPath(cmd)
+	if len(path) == 0 || path[0] == "config" || path[0] == "completion" || strings.HasPrefix(path[0], "__") {
+		return
+	}
+
+	fmt.Println("OpenLabs can send anonymous usage data to help improve the CLI: the command name, whether it")
+	fmt.Println("succeeded, how long it took, and the CLI version. Arguments and identifiers are never sent.")
+	enabled, err := utils.PromptConfirm("Send anonymous usage data?")
+	if err != nil {
+		return
+	}
+
+	if err := globalConfig.SetTelemetry(enabled); err != nil {
+		logger.Debug("Failed to save telemetry choice: %v", err)
+		return
+	}
+	progress.ShowInfo("Change this at any time with 'openlabs config set telemetry true|false'")
+	fmt.Println()
 }
 
 func handleError(err error, cmd *cobra.Command) {
-	if isUsageError(err) {
+	switch {
+	case isUsageError(err):
 		fmt.Fprintf(os.Stderr, "Error: %s\n\nRun 'openlabs --help' for usage.\n", err.Error())
-	} else {
+	case errors.Is(err, client.ErrUnauthorized):
+		output.DisplayError(err)
+		fmt.Fprintln(os.Stderr, "Your session may have expired. Run 'openlabs auth login' to sign in again.")
+	default:
 		output.DisplayError(err)
 	}
 }
@@ -86,9 +186,22 @@ func init() {
 
 func setupGlobalFlags() {
 	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "config file path (default: ~/.openlabs/config.json)")
+	rootCmd.PersistentFlags().StringVar(&configDir, "config-dir", "", "directory for the config file, keys, cache, and state (default: $OPENLABS_HOME or ~/.openlabs)")
 	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "", "output format (table, json, yaml)")
 	rootCmd.PersistentFlags().StringVar(&apiURL, "api-url", "", "OpenLabs API URL")
-	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&timeFormat, "time-format", "", "timestamp format (local, utc, rfc3339; default: local for tables, rfc3339 otherwise)")
+	rootCmd.PersistentFlags().BoolVar(&noDiscovery, "no-discovery", false, "use the API URL as is instead of resolving it through /.well-known/openlabs")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "fetch everything from the API instead of using cached responses")
+	rootCmd.PersistentFlags().BoolVar(&clearCache, "clear-cache", false, "delete all cached API responses before running the command")
+	rootCmd.PersistentFlags().DurationVar(&reqTimeout, "request-timeout", 0, "timeout for each API request, overriding the configured timeout and the command's default (e.g. 90s)")
+	rootCmd.PersistentFlags().BoolVar(&strict404, "strict-404", false, "treat every 404 from list commands as an error instead of an empty result")
+	rootCmd.PersistentFlags().BoolVar(&totals, "totals", false, "add a footer with row counts and column totals to list tables")
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", color.ModeAuto, "when to color output (auto, always, never); auto honors NO_COLOR and CLICOLOR_FORCE")
+	rootCmd.PersistentFlags().StringVar(&queryPath, "query", "", "print only the value at a path in the JSON result, e.g. vpcs[0].subnets[0].cidr")
+	rootCmd.PersistentFlags().StringVar(&alsoJSON, "also-json", "", "also write the result as JSON to this file")
+	rootCmd.PersistentFlags().StringVar(&alsoYAML, "also-yaml", "", "also write the result as YAML to this file")
+	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "increase log output; repeat for more (-v requests, -vv debug, -vvv request and response bodies). Debug output, which a single -v used to show, now takes -vv")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "log level (error, warn, info, debug, trace); overrides -v")
 }
 
 func addSubcommands() {
@@ -96,6 +209,8 @@ func addSubcommands() {
 	rootCmd.AddCommand(ranges.NewRangeCommand())
 	rootCmd.AddCommand(blueprints.NewBlueprintsCommand())
 	rootCmd.AddCommand(config.NewConfigCommand())
+	rootCmd.AddCommand(cache.NewCacheCommand())
+	rootCmd.AddCommand(audit.NewAuditCommand())
 }
 
 func initializeGlobalConfig() error {
@@ -114,25 +229,151 @@ func initializeGlobalConfig() error {
 	return nil
 }
 
-func applyGlobalFlags() {
+// effectiveLogLevel starts from warn, or debug when the config enables it, and raises the level once
+// per -v. An explicit --log-level wins.
+func effectiveLogLevel() (logger.Level, error) {
+	if logLevel != "" {
+		return logger.ParseLevel(logLevel)
+	}
+
+	level := logger.LevelWarn
+	if globalConfig.Debug {
+		level = logger.LevelDebug
+	}
+
+	level += logger.Level(verbosity)
+	if level > logger.LevelTrace {
+		level = logger.LevelTrace
+	}
+	return level, nil
+}
+
+// commandPath returns the names of cmd and its parents below the root, such as ["range", "jobs"].
+func commandPath(cmd *cobra.Command) []string {
+	var path []string
+	for ; cmd != nil && cmd.HasParent(); cmd = cmd.Parent() {
+		path = append([]string{cmd.Name()}, path...)
+	}
+	return path
+}
+
+// timeoutBounds limits the configured request timeout for one command. A zero bound is not applied.
+type timeoutBounds struct {
+	min, max time.Duration
+}
+
+// commandTimeouts bounds the configured timeout for commands whose requests are reliably quick or
+// slow, keyed by command path as in format overrides: status checks give up quickly, while
+// deploy and destroy submissions are not cut off by a short global timeout.
+var commandTimeouts = map[string]timeoutBounds{
+	"auth.status":   {max: 30 * time.Second},
+	"auth.whoami":   {max: 30 * time.Second},
+	"range.list":    {max: 30 * time.Second},
+	"range.status":  {max: 30 * time.Second},
+	"range.deploy":  {min: 10 * time.Minute},
+	"range.destroy": {min: 10 * time.Minute},
+}
+
+// requestTimeoutFor returns the request timeout for the command at path: --request-timeout when
+// given, otherwise the configured timeout within the command's bounds.
+func requestTimeoutFor(path []string, configured time.Duration) time.Duration {
+	if reqTimeout > 0 {
+		return reqTimeout
+	}
+
+	bounds := commandTimeouts[strings.Join(path, ".")]
+	timeout := configured
+	if bounds.max > 0 && timeout > bounds.max {
+		timeout = bounds.max
+	}
+	if bounds.min > 0 && timeout < bounds.min {
+		timeout = bounds.min
+	}
+	return timeout
+}
+
+func applyGlobalFlags(cmd *cobra.Command) error {
 	if apiURL != "" {
 		globalConfig.APIURL = apiURL
 	}
 
 	if outputFormat != "" {
-		globalConfig.OutputFormat = outputFormat
+		globalConfig.Format = outputFormat
+	} else {
+		globalConfig.Format = globalConfig.FormatFor(commandPath(cmd))
+	}
+
+	if timeFormat != "" {
+		globalConfig.TimeFormat = timeFormat
 	}
 
-	if verbose {
-		globalConfig.Debug = true
+	if queryPath != "" {
+		if err := output.SetQuery(queryPath); err != nil {
+			return err
+		}
+	}
+
+	if noDiscovery {
+		globalConfig.NoDiscovery = true
+	}
+
+	if strict404 {
+		globalConfig.Strict404 = true
 	}
 
-	// Set logger level based on debug flag
-	logger.SetDebug(globalConfig.Debug)
+	if noCache {
+		globalConfig.NoCache = true
+	}
+
+	if cmd.Flags().Changed("request-timeout") && reqTimeout <= 0 {
+		return fmt.Errorf("--request-timeout must be greater than 0")
+	}
+	globalConfig.RequestTimeout = requestTimeoutFor(commandPath(cmd), globalConfig.Timeout)
+
+	effectiveTimeFormat := globalConfig.TimeFormat
+	if effectiveTimeFormat == "" {
+		effectiveTimeFormat = output.DefaultTimeFormat(globalConfig.Format)
+	}
+	if err := output.SetTimeFormat(effectiveTimeFormat); err != nil {
+		return err
+	}
+
+	if err := color.SetMode(colorMode); err != nil {
+		return err
+	}
+
+	output.SetTotals(totals)
+	output.SetAlsoWrite(alsoJSON, alsoYAML)
+
+	level, err := effectiveLogLevel()
+	if err != nil {
+		return err
+	}
+	logger.SetLevel(level)
+
+	if clearCache {
+		clearResponseCache()
+	}
 
 	auth.SetGlobalConfig(globalConfig)
 	ranges.SetGlobalConfig(globalConfig)
 	blueprints.SetGlobalConfig(globalConfig)
+	cache.SetGlobalConfig(globalConfig)
+	audit.SetGlobalConfig(globalConfig)
+
+	return nil
+}
+
+// clearResponseCache purges cached API responses for --clear-cache. A stale cache only costs fresh
+// data, so failing to clear it is a warning rather than an error.
+func clearResponseCache() {
+	dir, err := internalConfig.GetCacheDir()
+	if err == nil {
+		err = internalCache.Open(dir).Clear()
+	}
+	if err != nil {
+		logger.Warn("Failed to clear the response cache: %v", err)
+	}
 }
 
 func loadConfigFromPath(path string) (*internalConfig.Config, error) {
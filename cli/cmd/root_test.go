@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	internalConfig "github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+)
+
+func TestKeyCommandsHaveExamples(t *testing.T) {
+	commands := []string{
+		"range deploy",
+		"range destroy",
+		"range list",
+		"range status",
+		"blueprints create",
+		"blueprints list",
+		"blueprints catalog",
+		"auth login",
+		"auth secrets",
+		"auth secrets aws",
+		"auth secrets azure",
+		"auth secrets gcp",
+		"config set",
+		"audit",
+	}
+
+	for _, path := range commands {
+		t.Run(path, func(t *testing.T) {
+			cmd, _, err := rootCmd.Find(strings.Fields(path))
+			if err != nil || strings.Join(commandPath(cmd), " ") != path {
+				t.Fatalf("command %q not found (got %v, error %v)", path, commandPath(cmd), err)
+			}
+
+			if strings.TrimSpace(cmd.Example) == "" {
+				t.Fatalf("openlabs %s has no Example", path)
+			}
+			// Examples show full invocations so they can be copied as is
+			if !strings.Contains(cmd.Example, "openlabs "+path) {
+				t.Errorf("openlabs %s examples never run the command:\n%s", path, cmd.Example)
+			}
+		})
+	}
+}
+
+func TestRequestTimeoutFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		configured time.Duration
+		flag       time.Duration
+		want       time.Duration
+	}{
+		{name: "unbounded command", path: "blueprints list", configured: 2 * time.Minute, want: 2 * time.Minute},
+		{name: "within the maximum", path: "range status", configured: 10 * time.Second, want: 10 * time.Second},
+		{name: "capped at the maximum", path: "range status", configured: 5 * time.Minute, want: 30 * time.Second},
+		{name: "within the minimum", path: "range deploy", configured: 20 * time.Minute, want: 20 * time.Minute},
+		{name: "raised to the minimum", path: "range deploy", configured: 30 * time.Second, want: 10 * time.Minute},
+		{name: "flag overrides the maximum", path: "auth status", configured: 5 * time.Minute, flag: 2 * time.Minute, want: 2 * time.Minute},
+		{name: "flag overrides the minimum", path: "range destroy", configured: 30 * time.Second, flag: 45 * time.Second, want: 45 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := reqTimeout
+			reqTimeout = tt.flag
+			t.Cleanup(func() { reqTimeout = previous })
+
+			if got := requestTimeoutFor(strings.Fields(tt.path), tt.configured); got != tt.want {
+				t.Errorf("requestTimeoutFor(%q, %v) = %v, want %v", tt.path, tt.configured, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandTimeoutsNameCommands(t *testing.T) {
+	for key := range commandTimeouts {
+		path := strings.Split(key, ".")
+		cmd, _, err := rootCmd.Find(path)
+		if err != nil || strings.Join(commandPath(cmd), ".") != key {
+			t.Errorf("commandTimeouts has %q, which is not a command", key)
+		}
+	}
+}
+
+func TestFormatOverrideIsNotSaved(t *testing.T) {
+	t.Setenv(internalConfig.HomeEnv, t.TempDir())
+	t.Cleanup(func() { globalConfig = nil })
+
+	globalConfig = &internalConfig.Config{
+		OutputFormat:    "table",
+		Timeout:         time.Minute,
+		FormatOverrides: map[string]string{"range.list": "yaml"},
+	}
+	cmd, _, err := rootCmd.Find([]string{"range", "list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applyGlobalFlags(cmd); err != nil {
+		t.Fatalf("applyGlobalFlags() error = %v", err)
+	}
+	if globalConfig.Format != "yaml" {
+		t.Errorf("Format = %q, want the override", globalConfig.Format)
+	}
+
+	if err := globalConfig.Save(); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := internalConfig.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.OutputFormat != "table" {
+		t.Errorf("saved output_format = %q, want the override kept out of it", saved.OutputFormat)
+	}
+}
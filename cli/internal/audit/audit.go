@@ -0,0 +1,163 @@
+// Package audit keeps an opt-in local record of the changes the CLI makes, such as deploying or
+// destroying a range. Entries are appended as JSON lines to audit.log in the openlabs directory. When
+// the file grows past maxSize it is renamed to audit.log.1, replacing the previous one, so the log
+// never takes more than about twice maxSize.
+//
+// Writing an entry never fails the command that made the change: errors are only logged.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+const (
+	fileName = "audit.log"
+	maxSize  = 1 << 20
+)
+
+const (
+	// OutcomeSubmitted means the server accepted a job that was not waited for
+	OutcomeSubmitted = "submitted"
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+)
+
+// Entry is one change made by the CLI.
+type Entry struct {
+	Time          time.Time `json:"time"`
+	Command       string    `json:"command"`
+	RangeID       int       `json:"range_id,omitempty"`
+	RangeName     string    `json:"range_name,omitempty"`
+	BlueprintID   int       `json:"blueprint_id,omitempty"`
+	BlueprintName string    `json:"blueprint_name,omitempty"`
+	JobID         string    `json:"job_id,omitempty"`
+	Outcome       string    `json:"outcome"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// mu serializes writes from commands that make changes concurrently, such as blueprints create-all.
+var mu sync.Mutex
+
+// Record appends entry to the audit log when cfg has it enabled, and does nothing otherwise. A zero
+// Time is set to now.
+func Record(cfg *config.Config, entry Entry) {
+	if cfg == nil || !cfg.AuditLog {
+		return
+	}
+
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	if err := write(entry); err != nil {
+		logger.Debug("Failed to write audit log: %v", err)
+	}
+}
+
+// RecordResult is Record for an operation that either failed with err or ended in outcome.
+func RecordResult(cfg *config.Config, entry Entry, outcome string, err error) {
+	entry.Outcome = outcome
+	if err != nil {
+		entry.Outcome = OutcomeFailed
+		entry.Error = err.Error()
+	}
+	Record(cfg, entry)
+}
+
+// Path returns where the audit log is kept.
+func Path() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, fileName), nil
+}
+
+func write(entry Entry) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size()+int64(len(line)) >= maxSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// Load returns every entry in the audit log, oldest first, including the rotated file. Lines that
+// cannot be parsed are skipped.
+func Load() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, p := range []string{path + ".1", path} {
+		fileEntries, err := readFile(p)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	return entries, nil
+}
+
+func readFile(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			logger.Debug("Skipping unreadable audit log line in %s: %v", path, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
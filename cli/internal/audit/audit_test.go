@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+)
+
+func TestPathFollowsConfigDir(t *testing.T) {
+	t.Setenv(config.HomeEnv, t.TempDir())
+	dir := t.TempDir()
+	config.SetConfigDir(dir)
+	t.Cleanup(func() { config.SetConfigDir("") })
+
+	path, err := Path()
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	if want := filepath.Join(dir, fileName); path != want {
+		t.Errorf("Path() = %s, want %s", path, want)
+	}
+}
@@ -0,0 +1,247 @@
+// Package cache stores short-lived values, such as API responses, with a time to live. A cache can
+// also persist its entries as files in a directory so they survive between commands.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+// Cache is a thread-safe key/value store whose entries expire after their TTL. Disk errors never
+// fail a call; they only cost a cache miss and are logged.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]entry
+	dir     string
+}
+
+type entry struct {
+	Key       string    `json:"key"`
+	Value     []byte    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (e entry) expired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+// New returns a cache that keeps entries in memory only.
+func New() *Cache {
+	return &Cache{entries: make(map[string]entry)}
+}
+
+// Open returns a cache that also persists entries as files in dir. The directory is created when
+// the first entry is written.
+func Open(dir string) *Cache {
+	c := New()
+	c.dir = dir
+	return c
+}
+
+// Dir returns the directory the cache persists to, or "" for a memory-only cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Get returns the value stored under key, or false when there is none or it has expired.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok && c.dir != "" {
+		e, ok = c.readEntry(key)
+	}
+	if !ok {
+		return nil, false
+	}
+
+	if e.expired() {
+		c.remove(key)
+		return nil, false
+	}
+
+	c.entries[key] = e
+	return e.Value, true
+}
+
+// Set stores value under key for ttl.
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := entry{Key: key, Value: value, ExpiresAt: time.Now().Add(ttl)}
+	c.entries[key] = e
+
+	if c.dir != "" {
+		c.writeEntry(e)
+	}
+}
+
+// Invalidate removes the entry stored under key.
+func (c *Cache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(key)
+}
+
+// InvalidatePrefix removes every entry whose key starts with prefix.
+func (c *Cache) InvalidatePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+
+	c.eachFile(func(path string, e entry) {
+		if strings.HasPrefix(e.Key, prefix) {
+			removeFile(path)
+		}
+	})
+}
+
+// Clear removes every entry. A cache directory that does not exist is already clear.
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]entry)
+
+	if c.dir == "" {
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats describes what a cache holds.
+type Stats struct {
+	Entries int   `json:"entries"`
+	Expired int   `json:"expired"`
+	Bytes   int64 `json:"bytes"`
+}
+
+// Stats counts the persisted entries and their size on disk, or the in-memory entries of a
+// memory-only cache. A cache directory that does not exist holds nothing.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var stats Stats
+	if c.dir == "" {
+		for _, e := range c.entries {
+			stats.Entries++
+			stats.Bytes += int64(len(e.Value))
+			if e.expired() {
+				stats.Expired++
+			}
+		}
+		return stats
+	}
+
+	c.eachFile(func(path string, e entry) {
+		stats.Entries++
+		if info, err := os.Stat(path); err == nil {
+			stats.Bytes += info.Size()
+		}
+		if e.expired() {
+			stats.Expired++
+		}
+	})
+	return stats
+}
+
+func (c *Cache) remove(key string) {
+	delete(c.entries, key)
+	if c.dir != "" {
+		removeFile(c.path(key))
+	}
+}
+
+// path returns the file an entry is persisted to. Keys are hashed because they may contain
+// characters that are not valid in file names.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *Cache) readEntry(key string) (entry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return entry{}, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil || e.Key != key {
+		logger.Debug("Ignoring unreadable cache entry for %s", key)
+		return entry{}, false
+	}
+	return e, true
+}
+
+func (c *Cache) writeEntry(e entry) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		logger.Debug("Failed to create cache directory: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(c.path(e.Key), data, 0600); err != nil {
+		logger.Debug("Failed to write cache entry: %v", err)
+	}
+}
+
+// eachFile calls fn for every readable entry file in the cache directory.
+func (c *Cache) eachFile(fn func(path string, e entry)) {
+	if c.dir == "" {
+		return
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return
+	}
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var e entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue
+		}
+		fn(path, e)
+	}
+}
+
+func removeFile(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		logger.Debug("Failed to remove cache entry: %v", err)
+	}
+}
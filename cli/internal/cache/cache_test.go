@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheGetSet(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		cache *Cache
+	}{
+		{name: "memory", cache: New()},
+		{name: "disk", cache: Open(t.TempDir())},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.cache
+			if _, ok := c.Get("missing"); ok {
+				t.Error("Get() of a missing key = true")
+			}
+
+			c.Set("a", []byte("1"), time.Minute)
+			if got, ok := c.Get("a"); !ok || string(got) != "1" {
+				t.Errorf("Get(a) = %q, %v, want 1, true", got, ok)
+			}
+
+			c.Set("a", []byte("2"), time.Minute)
+			if got, _ := c.Get("a"); string(got) != "2" {
+				t.Errorf("Get(a) after overwrite = %q, want 2", got)
+			}
+		})
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+
+	c.Set("short", []byte("1"), 10*time.Millisecond)
+	c.Set("long", []byte("2"), time.Hour)
+	time.Sleep(20 * time.Millisecond)
+
+	if stats := c.Stats(); stats.Entries != 2 || stats.Expired != 1 {
+		t.Errorf("Stats() = %+v, want 2 entries with 1 expired", stats)
+	}
+
+	if _, ok := c.Get("short"); ok {
+		t.Error("Get() of an expired entry = true")
+	}
+	if _, ok := c.Get("long"); !ok {
+		t.Error("Get() of a fresh entry = false")
+	}
+
+	// Reading an expired entry removes its file
+	if stats := Open(dir).Stats(); stats.Entries != 1 || stats.Expired != 0 {
+		t.Errorf("Stats() after reading the expired entry = %+v, want 1 fresh entry", stats)
+	}
+}
+
+func TestCachePersists(t *testing.T) {
+	dir := t.TempDir()
+	Open(dir).Set("key/with:odd chars?", []byte("value"), time.Minute)
+
+	got, ok := Open(dir).Get("key/with:odd chars?")
+	if !ok || string(got) != "value" {
+		t.Errorf("Get() from a new cache = %q, %v, want the persisted value", got, ok)
+	}
+
+	if _, ok := New().Get("key/with:odd chars?"); ok {
+		t.Error("memory-only cache read a persisted entry")
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+	c.Set("api|range/1", []byte("1"), time.Minute)
+	c.Set("api|range/2", []byte("2"), time.Minute)
+	c.Set("api|blueprint/1", []byte("3"), time.Minute)
+
+	c.Invalidate("api|range/1")
+	if _, ok := Open(dir).Get("api|range/1"); ok {
+		t.Error("Invalidate() left the entry on disk")
+	}
+
+	// Entries only on disk, written by an earlier command, are invalidated too
+	Open(dir).Set("api|range/3", []byte("4"), time.Minute)
+	c.InvalidatePrefix("api|range/")
+
+	fresh := Open(dir)
+	for _, key := range []string{"api|range/2", "api|range/3"} {
+		if _, ok := c.Get(key); ok {
+			t.Errorf("InvalidatePrefix() kept %s in memory", key)
+		}
+		if _, ok := fresh.Get(key); ok {
+			t.Errorf("InvalidatePrefix() kept %s on disk", key)
+		}
+	}
+	if _, ok := fresh.Get("api|blueprint/1"); !ok {
+		t.Error("InvalidatePrefix() removed an entry outside the prefix")
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+
+	if err := c.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if stats := Open(dir).Stats(); stats.Entries != 0 {
+		t.Errorf("Stats() after Clear = %+v, want no entries", stats)
+	}
+
+	if err := Open(filepath.Join(dir, "missing")).Clear(); err != nil {
+		t.Errorf("Clear() of a missing directory error = %v", err)
+	}
+}
+
+func TestCacheIgnoresUnreadableEntries(t *testing.T) {
+	dir := t.TempDir()
+	c := Open(dir)
+	c.Set("a", []byte("1"), time.Minute)
+
+	if err := os.WriteFile(c.path("a"), []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Open(dir).Get("a"); ok {
+		t.Error("Get() of a corrupt entry = true")
+	}
+
+	// A file holding another key's entry, as after a hash collision, is not used
+	other := Open(dir)
+	other.Set("b", []byte("2"), time.Minute)
+	data, err := os.ReadFile(other.path("b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(other.path("c"), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Open(dir).Get("c"); ok {
+		t.Error("Get() used an entry stored under another key")
+	}
+}
@@ -1,9 +1,14 @@
 package client
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
 )
@@ -17,18 +22,12 @@ func (c *Client) Login(email, password string) error {
 	var response LoginResponse
 	var authToken, encKey string
 
-	cookieHandler := func(cookies []*http.Cookie) {
-		for _, cookie := range cookies {
-			switch cookie.Name {
-			case "token", "access_token_cookie", "jwt", "auth_token", "access_token":
-				authToken = cookie.Value
-			case "enc_key":
-				encKey = cookie.Value
-			}
-		}
-	}
+	cookieHandler := authCookieHandler(&authToken, &encKey)
 
 	if err := c.makeRequestWithCookies("POST", "/api/v1/auth/login", credentials, &response, cookieHandler); err != nil {
+		if errors.Is(err, ErrUnauthorized) {
+			return fmt.Errorf("login failed: invalid email or password")
+		}
 		return fmt.Errorf("login failed: %w", err)
 	}
 
@@ -49,6 +48,79 @@ func (c *Client) Login(email, password string) error {
 	return nil
 }
 
+// RefreshToken exchanges the current session for a new one and stores the new token. It returns the
+// new expiry when known, or ErrNotSupported when the server has no refresh endpoint.
+func (c *Client) RefreshToken() (*time.Time, error) {
+	var response RefreshResponse
+	var authToken, encKey string
+
+	cookieHandler := authCookieHandler(&authToken, &encKey)
+
+	if err := c.makeRequestWithCookies("POST", "/api/v1/auth/refresh", nil, &response, cookieHandler); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("token refresh failed: %w", err)
+	}
+
+	if authToken == "" {
+		return nil, fmt.Errorf("no authentication token received from server")
+	}
+
+	if encKey == "" {
+		encKey = c.config.EncryptionKey
+	}
+
+	if err := c.config.SetCredentials(authToken, encKey); err != nil {
+		return nil, fmt.Errorf("failed to save credentials: %w", err)
+	}
+
+	if response.ExpiresAt != nil {
+		return response.ExpiresAt, nil
+	}
+
+	if expiry, ok := TokenExpiry(authToken); ok {
+		return &expiry, nil
+	}
+
+	return nil, nil
+}
+
+func authCookieHandler(authToken, encKey *string) func([]*http.Cookie) {
+	return func(cookies []*http.Cookie) {
+		for _, cookie := range cookies {
+			switch cookie.Name {
+			case "token", "access_token_cookie", "jwt", "auth_token", "access_token":
+				*authToken = cookie.Value
+			case "enc_key":
+				*encKey = cookie.Value
+			}
+		}
+	}
+}
+
+// TokenExpiry reads the exp claim from a JWT without verifying it.
+func TokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(claims.Exp, 0), true
+}
+
 func (c *Client) Logout() error {
 	if err := c.makeRequest("POST", "/api/v1/auth/logout", nil, nil); err != nil {
 		return fmt.Errorf("logout request failed: %w", err)
@@ -106,10 +178,54 @@ func (c *Client) UpdatePassword(currentPassword, newPassword string) error {
 	return nil
 }
 
+// GetQuota returns the account's resource limits and usage. It returns ErrNotSupported when the
+// server does not report quotas.
+func (c *Client) GetQuota() (*Quota, error) {
+	var quota Quota
+	if err := c.makeEnvelopedRequest("GET", "/api/v1/users/me/quota", nil, &quota); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to get quota: %w", err)
+	}
+	return &quota, nil
+}
+
+// CreateScopedToken requests a token limited to scopes that expires after ttl. It returns
+// ErrNotSupported if the server does not issue scoped tokens.
+func (c *Client) CreateScopedToken(scopes []string, ttl time.Duration) (*ScopedToken, error) {
+	request := CreateTokenRequest{
+		Scopes:     scopes,
+		TTLSeconds: int(ttl.Seconds()),
+	}
+
+	var token ScopedToken
+	if err := c.makeEnvelopedRequest("POST", "/api/v1/users/me/tokens", request, &token); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to create token: %w", err)
+	}
+	return &token, nil
+}
+
+// RevokeScopedToken invalidates a scoped token before it expires. It returns ErrNotSupported if the
+// server does not issue scoped tokens.
+func (c *Client) RevokeScopedToken(id string) error {
+	var response Message
+	if err := c.makeRequest("DELETE", fmt.Sprintf("/api/v1/users/me/tokens/%s", url.PathEscape(id)), nil, &response); err != nil {
+		if isNotSupported(err) {
+			return ErrNotSupported
+		}
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
 func (c *Client) GetUserSecrets() (*UserSecretResponse, error) {
 	var secrets UserSecretResponse
 	if err := c.makeRequest("GET", "/api/v1/users/me/secrets", nil, &secrets); err != nil {
-		return nil, fmt.Errorf("failed to get user secrets: %w", err)
+		return nil, fmt.Errorf("failed to get user secrets: %w", encryptionKeyError(err))
 	}
 	return &secrets, nil
 }
@@ -122,7 +238,7 @@ func (c *Client) UpdateAWSSecrets(accessKey, secretKey string) error {
 
 	var response Message
 	if err := c.makeRequest("POST", "/api/v1/users/me/secrets/aws", secrets, &response); err != nil {
-		return fmt.Errorf("failed to update AWS secrets: %w", err)
+		return fmt.Errorf("failed to update AWS secrets: %w", encryptionKeyError(err))
 	}
 
 	return nil
@@ -138,12 +254,95 @@ func (c *Client) UpdateAzureSecrets(clientID, clientSecret, tenantID, subscripti
 
 	var response Message
 	if err := c.makeRequest("POST", "/api/v1/users/me/secrets/azure", secrets, &response); err != nil {
-		return fmt.Errorf("failed to update Azure secrets: %w", err)
+		return fmt.Errorf("failed to update Azure secrets: %w", encryptionKeyError(err))
+	}
+
+	return nil
+}
+
+func (c *Client) UpdateGCPSecrets(projectID, saJSON string) error {
+	secrets := GCPSecrets{
+		ProjectID:          projectID,
+		ServiceAccountJSON: saJSON,
+	}
+
+	var response Message
+	if err := c.makeRequest("POST", "/api/v1/users/me/secrets/gcp", secrets, &response); err != nil {
+		return fmt.Errorf("failed to update GCP secrets: %w", encryptionKeyError(err))
 	}
 
 	return nil
 }
 
+// ErrCredentialsRejected is returned by ValidateSecrets when the cloud provider refuses the credentials.
+var ErrCredentialsRejected = errors.New("credentials rejected")
+
+// ValidateSecrets asks the server to check cloud provider credentials without saving them. creds is an
+// AWSSecrets, AzureSecrets, or GCPSecrets value. It returns ErrNotSupported if the server cannot
+// validate credentials.
+func (c *Client) ValidateSecrets(provider string, creds interface{}) error {
+	var response Message
+	err := c.makeRequest("POST", fmt.Sprintf("/api/v1/users/me/secrets/%s/validate", provider), creds, &response)
+	if err == nil {
+		return nil
+	}
+
+	if isNotSupported(err) {
+		return ErrNotSupported
+	}
+
+	if keyErr := encryptionKeyError(err); errors.Is(keyErr, ErrEncryptionKeyInvalid) {
+		return keyErr
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusUnprocessableEntity:
+			reason := httpErr.Message
+			if httpErr.Details != nil {
+				reason = fmt.Sprint(httpErr.Details)
+			}
+			return fmt.Errorf("%w: %s", ErrCredentialsRejected, reason)
+		}
+	}
+
+	return fmt.Errorf("failed to validate %s secrets: %w", provider, err)
+}
+
+// ErrEncryptionKeyInvalid is returned when the server cannot decrypt the user's secrets with the
+// session's encryption key, the enc_key cookie captured at login. The token itself is still valid,
+// so this is reported apart from an expired session.
+var ErrEncryptionKeyInvalid = errors.New("encryption key is missing or no longer valid. Run 'openlabs auth login' to sign in again and restore it")
+
+// encryptionKeyMarkers are phrases in server error details that mean the session's encryption key
+// is missing or cannot decrypt the user's secrets. They are specific enough not to match other key
+// problems, such as an account whose encryption keys were never set up.
+var encryptionKeyMarkers = []string{
+	"encryption key not found",
+	"invalid encryption key",
+	"invalid enc_key",
+}
+
+// encryptionKeyError wraps ErrEncryptionKeyInvalid, keeping the server's message, when err is a
+// server error about the session's encryption key, and returns err unchanged otherwise.
+func encryptionKeyError(err error) error {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode < 400 {
+		return err
+	}
+
+	detail := fmt.Sprint(httpErr.Details)
+	lower := strings.ToLower(detail)
+	for _, marker := range encryptionKeyMarkers {
+		if strings.Contains(lower, marker) {
+			logger.Debug("Server rejected the encryption key: %v", err)
+			return fmt.Errorf("%w (server: %s)", ErrEncryptionKeyInvalid, detail)
+		}
+	}
+	return err
+}
+
 type AuthCookies struct {
 	AuthToken     string
 	EncryptionKey string
@@ -185,7 +384,7 @@ func (c *Client) extractAuthCookies() AuthCookies {
 }
 
 func (c *Client) IsAuthenticated() bool {
-	return c.config.AuthToken != ""
+	return c.config.Token() != ""
 }
 
 func parseURL(urlStr string) (*url.URL, error) {
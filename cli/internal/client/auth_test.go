@@ -0,0 +1,46 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestEncryptionKeyError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		detail  string
+		wantKey bool
+	}{
+		{name: "key cookie missing", status: http.StatusUnauthorized, detail: "Encryption key not found. Please try logging in again.", wantKey: true},
+		{name: "key cookie invalid", status: http.StatusBadRequest, detail: "Invalid encryption key. Please try logging in again.", wantKey: true},
+		{name: "secrets cannot be decrypted", status: http.StatusBadRequest, detail: "Failed to decrypt user secrets: invalid enc_key", wantKey: true},
+		{name: "account keys never set up", status: http.StatusBadRequest, detail: "User encryption keys not set up. Please register a new account.", wantKey: false},
+		{name: "expired token", status: http.StatusUnauthorized, detail: "Could not validate credentials", wantKey: false},
+		{name: "unrelated server error", status: http.StatusInternalServerError, detail: "Internal Server Error", wantKey: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				testutil.RespondJSON(w, tt.status, `{"detail":"`+tt.detail+`"}`)
+			})
+
+			_, err := apiClient.GetUserSecrets()
+			if err == nil {
+				t.Fatal("GetUserSecrets() error = nil")
+			}
+
+			if got := errors.Is(err, ErrEncryptionKeyInvalid); got != tt.wantKey {
+				t.Fatalf("errors.Is(err, ErrEncryptionKeyInvalid) = %v, want %v (err: %v)", got, tt.wantKey, err)
+			}
+			if !strings.Contains(err.Error(), tt.detail) {
+				t.Errorf("error %q does not include the server message %q", err, tt.detail)
+			}
+		})
+	}
+}
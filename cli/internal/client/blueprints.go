@@ -1,11 +1,30 @@
 package client
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
 
 func (c *Client) ListBlueprintRanges() ([]BlueprintRangeHeader, error) {
-	var blueprints []BlueprintRangeHeader
-	if err := c.makeRequest("GET", "/api/v1/blueprints/ranges", nil, &blueprints); err != nil {
-		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == 404 {
+	return c.ListBlueprintRangesWithOptions(ListOptions{})
+}
+
+// ListBlueprintRangesWithOptions lists blueprints with server-side paging and sorting where the server
+// supports it.
+func (c *Client) ListBlueprintRangesWithOptions(opts ListOptions) ([]BlueprintRangeHeader, error) {
+	blueprints := []BlueprintRangeHeader{}
+	err := c.collectPages("/api/v1/blueprints/ranges", opts, func(data json.RawMessage) (int, error) {
+		var page []BlueprintRangeHeader
+		if err := json.Unmarshal(data, &page); err != nil {
+			return 0, err
+		}
+		blueprints = append(blueprints, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		if c.isEmptyListError(err) {
 			return []BlueprintRangeHeader{}, nil
 		}
 		return nil, fmt.Errorf("failed to list blueprint ranges: %w", err)
@@ -16,12 +35,101 @@ func (c *Client) ListBlueprintRanges() ([]BlueprintRangeHeader, error) {
 func (c *Client) GetBlueprintRange(id int) (*BlueprintRange, error) {
 	var blueprint BlueprintRange
 	path := fmt.Sprintf("/api/v1/blueprints/ranges/%d", id)
-	if err := c.makeRequest("GET", path, nil, &blueprint); err != nil {
+	if err := c.cachedGet(path, blueprintCacheTTL, &blueprint); err != nil {
 		return nil, fmt.Errorf("failed to get blueprint range %d: %w", id, err)
 	}
 	return &blueprint, nil
 }
 
+// GetBlueprintRangeRaw returns the blueprint exactly as the server sent it, including fields the
+// BlueprintRange type does not model.
+func (c *Client) GetBlueprintRangeRaw(id int) (json.RawMessage, error) {
+	var raw json.RawMessage
+	path := fmt.Sprintf("/api/v1/blueprints/ranges/%d", id)
+	if err := c.cachedGet(path, blueprintCacheTTL, &raw); err != nil {
+		return nil, fmt.Errorf("failed to get blueprint range %d: %w", id, err)
+	}
+	return raw, nil
+}
+
+// ListPublicBlueprints returns the blueprints in the server's public catalog. It returns
+// ErrNotSupported when the server has no catalog. Servers without one route the path to
+// /blueprints/ranges/{blueprint_id} and reject "public" as an ID with a 422, which counts as
+// unsupported too, so support does not depend on what the catalog holds.
+func (c *Client) ListPublicBlueprints(filter CatalogFilter) ([]CatalogBlueprint, error) {
+	query := url.Values{}
+	if filter.Provider != "" {
+		query.Set("provider", filter.Provider)
+	}
+	for _, tag := range filter.Tags {
+		query.Add("tag", tag)
+	}
+
+	path := "/api/v1/blueprints/ranges/public"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	blueprints := []CatalogBlueprint{}
+	if err := c.makeRequest("GET", path, nil, &blueprints); err != nil {
+		if c.isEmptyListError(err) {
+			return []CatalogBlueprint{}, nil
+		}
+		if isNotSupported(err) || errors.Is(err, ErrInvalid) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to list public blueprints: %w", err)
+	}
+	return blueprints, nil
+}
+
+// GetPublicBlueprint returns a blueprint from the server's public catalog, which may belong to
+// another user. It is fetched from the catalog rather than the owner-scoped blueprint endpoint, and
+// is not cached with the user's own blueprints. It returns ErrNotSupported when the server has no
+// catalog.
+func (c *Client) GetPublicBlueprint(id int) (*BlueprintRange, error) {
+	var blueprint BlueprintRange
+	path := fmt.Sprintf("/api/v1/blueprints/ranges/public/%d", id)
+	if err := c.makeRequest("GET", path, nil, &blueprint); err != nil {
+		if isMissingRoute(err) || errors.Is(err, ErrInvalid) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to get public blueprint %d: %w", id, err)
+	}
+	return &blueprint, nil
+}
+
+// ListBlueprintVersions returns the saved versions of a blueprint, oldest first. It returns
+// ErrNotSupported when the server does not keep blueprint versions, and an error matching
+// ErrNotFound when it does but has no such blueprint.
+func (c *Client) ListBlueprintVersions(id int) ([]BlueprintVersion, error) {
+	var versions []BlueprintVersion
+	path := fmt.Sprintf("/api/v1/blueprints/ranges/%d/versions", id)
+	if err := c.makeRequest("GET", path, nil, &versions); err != nil {
+		if isMissingRoute(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to list versions of blueprint range %d: %w", id, err)
+	}
+	return versions, nil
+}
+
+// GetBlueprintVersion returns a blueprint as it was at the given version. Versions never change once
+// saved, so they are cached like the current blueprint. It returns ErrNotSupported when the server
+// does not keep blueprint versions, and an error matching ErrNotFound when it does but has no such
+// blueprint or version.
+func (c *Client) GetBlueprintVersion(id, version int) (*BlueprintRange, error) {
+	var blueprint BlueprintRange
+	path := fmt.Sprintf("/api/v1/blueprints/ranges/%d/versions/%d", id, version)
+	if err := c.cachedGet(path, blueprintCacheTTL, &blueprint); err != nil {
+		if isMissingRoute(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to get version %d of blueprint range %d: %w", version, id, err)
+	}
+	return &blueprint, nil
+}
+
 func (c *Client) CreateBlueprintRange(blueprint interface{}) (*BlueprintRangeHeader, error) {
 	var result BlueprintRangeHeader
 	if err := c.makeRequest("POST", "/api/v1/blueprints/ranges", blueprint, &result); err != nil {
@@ -30,11 +138,26 @@ func (c *Client) CreateBlueprintRange(blueprint interface{}) (*BlueprintRangeHea
 	return &result, nil
 }
 
+// ValidateBlueprintRange asks the server to validate a blueprint without creating it. An invalid
+// blueprint fails with the same field errors a create would return. It returns ErrNotSupported if
+// the server cannot validate blueprints.
+func (c *Client) ValidateBlueprintRange(blueprint interface{}) error {
+	var response json.RawMessage
+	if err := c.makeRequest("POST", "/api/v1/blueprints/ranges/validate", blueprint, &response); err != nil {
+		if isNotSupported(err) {
+			return ErrNotSupported
+		}
+		return fmt.Errorf("failed to validate blueprint range: %w", err)
+	}
+	return nil
+}
+
 func (c *Client) DeleteBlueprintRange(id int) error {
 	path := fmt.Sprintf("/api/v1/blueprints/ranges/%d", id)
 	if err := c.makeRequest("DELETE", path, nil, nil); err != nil {
 		return fmt.Errorf("failed to delete blueprint range %d: %w", id, err)
 	}
+	c.invalidateCached(path)
 	return nil
 }
 
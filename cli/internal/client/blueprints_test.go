@@ -0,0 +1,220 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestListPublicBlueprints(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    int
+		wantErr error
+	}{
+		{name: "catalog", status: http.StatusOK, body: `[{"id":99,"name":"web-lab","provider":"aws","public":true},{"id":98,"name":"ad-lab","provider":"azure","public":true}]`, want: 2},
+		{name: "empty catalog", status: http.StatusOK, body: `[]`, want: 0},
+		{name: "empty catalog message", status: http.StatusNotFound, body: `{"detail":"No public blueprints found!"}`, want: 0},
+		{
+			name:    "old server with zero blueprints",
+			status:  http.StatusUnprocessableEntity,
+			body:    `{"detail":[{"loc":["path","blueprint_id"],"msg":"Input should be a valid integer","type":"int_parsing"}]}`,
+			wantErr: ErrNotSupported,
+		},
+		{name: "no route", status: http.StatusNotFound, body: `{"detail":"Not Found"}`, wantErr: ErrNotSupported},
+		{name: "server error", status: http.StatusInternalServerError, body: `{"detail":"boom"}`, wantErr: ErrServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/blueprints/ranges/public" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				testutil.RespondJSON(w, tt.status, tt.body)
+			})
+
+			blueprints, err := apiClient.ListPublicBlueprints(CatalogFilter{})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ListPublicBlueprints() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListPublicBlueprints() error = %v", err)
+			}
+			if blueprints == nil || len(blueprints) != tt.want {
+				t.Errorf("ListPublicBlueprints() = %v, want %d blueprints", blueprints, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPublicBlueprintsFilter(t *testing.T) {
+	var query string
+	apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		testutil.RespondJSON(w, http.StatusOK, `[]`)
+	})
+
+	if _, err := apiClient.ListPublicBlueprints(CatalogFilter{Provider: "aws", Tags: []string{"web", "beginner"}}); err != nil {
+		t.Fatalf("ListPublicBlueprints() error = %v", err)
+	}
+	if want := "provider=aws&tag=web&tag=beginner"; query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+}
+
+// versionedAPI fakes a server that keeps versions 1 and 2 of blueprint 5 and no versions of
+// blueprint 6, and has no blueprint 99.
+func versionedAPI(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/blueprints/ranges/99/versions", "/api/v1/blueprints/ranges/99/versions/1":
+			testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Blueprint range with ID: 99 not found!"}`)
+		case "/api/v1/blueprints/ranges/5/versions":
+			testutil.RespondJSON(w, http.StatusOK, `[{"version":1,"created_at":"2026-01-02T03:04:05Z","name":"web-lab"},{"version":2,"created_at":"2026-02-03T04:05:06Z","name":"web-lab-v2"}]`)
+		case "/api/v1/blueprints/ranges/6/versions":
+			testutil.RespondJSON(w, http.StatusOK, `[]`)
+		case "/api/v1/blueprints/ranges/5/versions/1":
+			testutil.RespondJSON(w, http.StatusOK, `{"id":5,"name":"web-lab","provider":"aws","vpcs":[]}`)
+		case "/api/v1/blueprints/ranges/5/versions/2":
+			testutil.RespondJSON(w, http.StatusOK, `{"id":5,"name":"web-lab-v2","provider":"aws","vpcs":[{"id":1,"name":"vpc","cidr":"10.0.0.0/16"}]}`)
+		default:
+			if r.Method != "GET" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			}
+			testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Not Found"}`)
+		}
+	}
+}
+
+func TestListBlueprintVersions(t *testing.T) {
+	apiClient := newTestClient(t, versionedAPI(t))
+
+	versions, err := apiClient.ListBlueprintVersions(5)
+	if err != nil {
+		t.Fatalf("ListBlueprintVersions() error = %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 1 || versions[1].Version != 2 || versions[1].Name != "web-lab-v2" {
+		t.Errorf("ListBlueprintVersions() = %+v, want versions 1 and 2", versions)
+	}
+	if versions[0].CreatedAt.IsZero() {
+		t.Error("ListBlueprintVersions() dropped created_at")
+	}
+
+	if versions, err := apiClient.ListBlueprintVersions(6); err != nil || len(versions) != 0 {
+		t.Errorf("ListBlueprintVersions() of an unversioned blueprint = %v, %v, want none", versions, err)
+	}
+
+	if _, err := apiClient.ListBlueprintVersions(99); !errors.Is(err, ErrNotFound) || errors.Is(err, ErrNotSupported) {
+		t.Errorf("ListBlueprintVersions() of a missing blueprint error = %v, want %v", err, ErrNotFound)
+	}
+
+	unversioned := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.RespondJSON(w, http.StatusNotFound, `{"detail":"Not Found"}`)
+	})
+	if _, err := unversioned.ListBlueprintVersions(5); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("ListBlueprintVersions() without versioning error = %v, want %v", err, ErrNotSupported)
+	}
+}
+
+func TestGetBlueprintVersion(t *testing.T) {
+	apiClient := newTestClient(t, versionedAPI(t))
+
+	tests := []struct {
+		version  int
+		wantName string
+		wantVPCs int
+		wantErr  error
+	}{
+		{version: 1, wantName: "web-lab"},
+		{version: 2, wantName: "web-lab-v2", wantVPCs: 1},
+		{version: 3, wantErr: ErrNotSupported},
+	}
+
+	if _, err := apiClient.GetBlueprintVersion(99, 1); !errors.Is(err, ErrNotFound) || errors.Is(err, ErrNotSupported) {
+		t.Errorf("GetBlueprintVersion() of a missing blueprint error = %v, want %v", err, ErrNotFound)
+	}
+
+	for _, tt := range tests {
+		blueprint, err := apiClient.GetBlueprintVersion(5, tt.version)
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetBlueprintVersion(5, %d) error = %v, want %v", tt.version, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("GetBlueprintVersion(5, %d) error = %v", tt.version, err)
+		}
+		if blueprint.Name != tt.wantName || len(blueprint.VPCs) != tt.wantVPCs {
+			t.Errorf("GetBlueprintVersion(5, %d) = %s with %d VPCs, want %s with %d", tt.version, blueprint.Name, len(blueprint.VPCs), tt.wantName, tt.wantVPCs)
+		}
+	}
+}
+
+func TestGetPublicBlueprint(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "catalog blueprint", status: http.StatusOK, body: `{"id":42,"name":"web-lab","provider":"aws","vpcs":[]}`},
+		{name: "not in the catalog", status: http.StatusNotFound, body: `{"detail":"Blueprint range with ID: 42 not found!"}`, wantErr: ErrNotFound},
+		{name: "no catalog route", status: http.StatusNotFound, body: `{"detail":"Not Found"}`, wantErr: ErrNotSupported},
+		{name: "method not allowed", status: http.StatusMethodNotAllowed, body: `{"detail":"Method Not Allowed"}`, wantErr: ErrNotSupported},
+		{
+			name:    "path taken by the blueprint ID route",
+			status:  http.StatusUnprocessableEntity,
+			body:    `{"detail":[{"loc":["path","blueprint_id"],"msg":"Input should be a valid integer","type":"int_parsing"}]}`,
+			wantErr: ErrNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/blueprints/ranges/public/42" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				testutil.RespondJSON(w, tt.status, tt.body)
+			})
+
+			blueprint, err := apiClient.GetPublicBlueprint(42)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetPublicBlueprint() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPublicBlueprint() error = %v", err)
+			}
+			if blueprint.ID != 42 || blueprint.Name != "web-lab" {
+				t.Errorf("GetPublicBlueprint() = %d %s, want 42 web-lab", blueprint.ID, blueprint.Name)
+			}
+		})
+	}
+}
+
+func TestGetPublicBlueprintNotCached(t *testing.T) {
+	var requests atomic.Int32
+	apiClient, _ := newCachingClient(t, &requests)
+
+	for i := 0; i < 2; i++ {
+		if _, err := apiClient.GetPublicBlueprint(42); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("sent %d requests for two fetches, want catalog blueprints left out of the cache", n)
+	}
+}
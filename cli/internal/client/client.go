@@ -2,34 +2,37 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/cache"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
 )
 
+// maxResponseBodySize caps how much of a response body is read, so a misrouted request that returns
+// a huge page cannot exhaust memory.
+const maxResponseBodySize = 16 << 20
+
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	config     *config.Config
-}
-
-type HTTPError struct {
-	StatusCode int
-	Message    string
-	Details    interface{}
-}
+	cache      *cache.Cache
 
-func (e *HTTPError) Error() string {
-	if e.Details != nil {
-		return fmt.Sprintf("HTTP %d: %s - %v", e.StatusCode, e.Message, e.Details)
-	}
-	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+	discoverOnce sync.Once
+	discoverErr  error
 }
 
 func New(cfg *config.Config) *Client {
@@ -41,9 +44,11 @@ func New(cfg *config.Config) *Client {
 	return &Client{
 		baseURL: cfg.APIURL,
 		config:  cfg,
+		cache:   newResponseCache(cfg),
 		httpClient: &http.Client{
-			Timeout: cfg.Timeout,
-			Jar:     jar,
+			Transport: chain(sharedTransport, defaultMiddlewares(cfg)...),
+			Timeout:   cfg.HTTPTimeout(),
+			Jar:       jar,
 		},
 	}
 }
@@ -53,6 +58,15 @@ func (c *Client) makeRequest(method, path string, body interface{}, result inter
 }
 
 func (c *Client) makeRequestWithCookies(method, path string, body interface{}, result interface{}, cookieHandler func([]*http.Cookie)) error {
+	return c.makeRequestContext(context.Background(), method, path, body, result, cookieHandler)
+}
+
+// makeRequestContext is makeRequestWithCookies with a context that can cancel the request.
+func (c *Client) makeRequestContext(ctx context.Context, method, path string, body interface{}, result interface{}, cookieHandler func([]*http.Cookie)) error {
+	if err := c.discover(); err != nil {
+		return fmt.Errorf("API discovery failed: %w", err)
+	}
+
 	requestURL := c.baseURL + path
 
 	var reqBody io.Reader
@@ -64,7 +78,7 @@ func (c *Client) makeRequestWithCookies(method, path string, body interface{}, r
 		reqBody = bytes.NewReader(jsonData)
 	}
 
-	req, err := http.NewRequest(method, requestURL, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reqBody)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -73,17 +87,12 @@ func (c *Client) makeRequestWithCookies(method, path string, body interface{}, r
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	c.addAuthenticationHeaders(req)
-
-	logger.Debug("Making request to %s %s", method, requestURL)
-
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	logger.Debug("Response status: %s", resp.Status)
 	logger.Debug("Response cookies: %d received", len(resp.Cookies()))
 
 	if cookieHandler != nil {
@@ -93,10 +102,10 @@ func (c *Client) makeRequestWithCookies(method, path string, body interface{}, r
 	return c.handleResponse(resp, result)
 }
 
-func (c *Client) addAuthenticationHeaders(req *http.Request) {
-	logger.Debug("Auth token available: %t", c.config.AuthToken != "")
-
-	if c.config.AuthToken == "" {
+// addAuthenticationCookies adds the token and encryption key cookies from cfg to req.
+func addAuthenticationCookies(req *http.Request, cfg *config.Config) {
+	token := cfg.Token()
+	if token == "" {
 		logger.Debug("No auth token available")
 		return
 	}
@@ -110,7 +119,7 @@ func (c *Client) addAuthenticationHeaders(req *http.Request) {
 	isSecure := parsedURL.Scheme == "https"
 	tokenCookie := &http.Cookie{
 		Name:     "token",
-		Value:    c.config.AuthToken,
+		Value:    token,
 		Path:     "/",
 		Domain:   parsedURL.Hostname(),
 		HttpOnly: true,
@@ -120,10 +129,10 @@ func (c *Client) addAuthenticationHeaders(req *http.Request) {
 
 	logger.Debug("Added token cookie")
 
-	if c.config.EncryptionKey != "" {
+	if cfg.EncryptionKey != "" {
 		encCookie := &http.Cookie{
 			Name:     "enc_key",
-			Value:    c.config.EncryptionKey,
+			Value:    cfg.EncryptionKey,
 			Path:     "/",
 			Domain:   parsedURL.Hostname(),
 			HttpOnly: true,
@@ -136,16 +145,30 @@ func (c *Client) addAuthenticationHeaders(req *http.Request) {
 }
 
 func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
-	body, err := io.ReadAll(resp.Body)
+	reader, err := decodedBody(resp)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	body, err := io.ReadAll(io.LimitReader(reader, maxResponseBodySize+1))
 	if err != nil {
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if len(body) > maxResponseBodySize {
+		return fmt.Errorf("response body exceeds %d bytes", maxResponseBodySize)
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return c.parseErrorResponse(resp.StatusCode, body)
 	}
 
 	if result != nil && len(body) > 0 {
+		if err := checkJSONContentType(resp.Header.Get("Content-Type")); err != nil {
+			return err
+		}
+
 		if err := json.Unmarshal(body, result); err != nil {
 			return fmt.Errorf("failed to parse response: %w", err)
 		}
@@ -154,6 +177,46 @@ func (c *Client) handleResponse(resp *http.Response, result interface{}) error {
 	return nil
 }
 
+// decodedBody returns a reader for the uncompressed response body. The transport requests gzip and
+// decompresses it itself because the client never sets Accept-Encoding; this covers responses that
+// still arrive gzip-encoded, such as those a proxy compresses on its own. Closing the reader does not
+// close the response body.
+func decodedBody(resp *http.Response) (io.ReadCloser, error) {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return io.NopCloser(resp.Body), nil
+	}
+
+	logger.Debug("Decompressing gzip-encoded response")
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.NopCloser(resp.Body), nil
+		}
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	return gz, nil
+}
+
+// checkJSONContentType rejects responses that declare a non-JSON content type, such as an HTML
+// error page from a proxy. A missing content type is accepted.
+func checkJSONContentType(contentType string) error {
+	if contentType == "" {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("invalid response content type %q: %w", contentType, err)
+	}
+
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		return nil
+	}
+
+	return fmt.Errorf("expected JSON response, got %s (check the API URL)", mediaType)
+}
+
 func (c *Client) parseErrorResponse(statusCode int, body []byte) error {
 	var errorData map[string]interface{}
 
@@ -173,6 +236,38 @@ func (c *Client) parseErrorResponse(statusCode int, body []byte) error {
 	}
 }
 
+// isEmptyListError reports whether err is the 404 the API returns from a list endpoint when the user
+// has nothing to list, such as "Unable to find any deployed ranges that you own!". A bare 404 from a
+// routing miss, usually a wrong API URL, does not qualify. With Strict404 set no 404 qualifies.
+func (c *Client) isEmptyListError(err error) bool {
+	if c.config.Strict404 {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &httpErr) {
+		return false
+	}
+
+	detail, ok := httpErr.Details.(string)
+	if !ok {
+		return false
+	}
+
+	// Regular users get "Unable to find any ... that you own!", admins get "No ... found!"
+	detail = strings.ToLower(detail)
+	return strings.HasPrefix(detail, "unable to find any ") ||
+		(strings.HasPrefix(detail, "no ") && strings.HasSuffix(detail, " found!"))
+}
+
 func (c *Client) Ping() error {
-	return c.makeRequest("GET", "/api/v1/health/ping", nil, nil)
+	_, err := c.PingWithLatency()
+	return err
+}
+
+// PingWithLatency pings the API and returns the round-trip time of the request.
+func (c *Client) PingWithLatency() (time.Duration, error) {
+	start := time.Now()
+	err := c.makeRequest("GET", "/api/v1/health/ping", nil, nil)
+	return time.Since(start), err
 }
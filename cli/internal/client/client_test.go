@@ -0,0 +1,256 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestHandleResponseSizeLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		wantErr bool
+	}{
+		{name: "at the limit", size: maxResponseBodySize},
+		{name: "one byte over", size: maxResponseBodySize + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A JSON string of exactly tt.size bytes, quotes included
+			body := `"` + strings.Repeat("a", tt.size-2) + `"`
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				testutil.RespondJSON(w, http.StatusOK, body)
+			})
+
+			var result string
+			err := apiClient.makeRequest("GET", "/api/v1/large", nil, &result)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "response body exceeds") {
+					t.Fatalf("makeRequest() error = %v, want the size limit error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("makeRequest() error = %v", err)
+			}
+			if len(result) != tt.size-2 {
+				t.Errorf("decoded %d bytes, want %d", len(result), tt.size-2)
+			}
+		})
+	}
+}
+
+func TestHandleResponseContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantErr     string
+	}{
+		{name: "json", contentType: "application/json", body: `{"message":"ok"}`},
+		{name: "json with charset", contentType: "application/json; charset=utf-8", body: `{"message":"ok"}`},
+		{name: "json suffix", contentType: "application/problem+json", body: `{"message":"ok"}`},
+		{name: "no content type", body: `{"message":"ok"}`},
+		{name: "html page", contentType: "text/html; charset=utf-8", body: "<html>login</html>", wantErr: "expected JSON response, got text/html (check the API URL)"},
+		{name: "plain text", contentType: "text/plain", body: "ok", wantErr: "expected JSON response, got text/plain"},
+		{name: "malformed content type", contentType: "application/json; =", body: `{"message":"ok"}`, wantErr: "invalid response content type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				// Setting an empty slice keeps net/http from sniffing a content type
+				w.Header()["Content-Type"] = nil
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			var result Message
+			err := apiClient.makeRequest("GET", "/api/v1/health/ping", nil, &result)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("makeRequest() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("makeRequest() error = %v", err)
+			}
+			if result.Message != "ok" {
+				t.Errorf("message = %q, want %q", result.Message, "ok")
+			}
+		})
+	}
+}
+
+func TestHandleResponseIgnoresContentTypeWithoutResult(t *testing.T) {
+	apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("pong"))
+	})
+
+	if err := apiClient.makeRequest("GET", "/api/v1/health/ping", nil, nil); err != nil {
+		t.Fatalf("makeRequest() error = %v", err)
+	}
+}
+
+func gzipped(t *testing.T, data string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestHandleResponseGzip(t *testing.T) {
+	compressed := gzipped(t, `{"message":"ok"}`)
+
+	t.Run("decompressed by the transport", func(t *testing.T) {
+		apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(compressed)
+		})
+
+		var result Message
+		if err := apiClient.makeRequest("GET", "/api/v1/health/ping", nil, &result); err != nil {
+			t.Fatalf("makeRequest() error = %v", err)
+		}
+		if result.Message != "ok" {
+			t.Errorf("message = %q, want %q", result.Message, "ok")
+		}
+	})
+
+	// Responses the transport leaves compressed are decompressed by handleResponse
+	tests := []struct {
+		name    string
+		body    []byte
+		want    string
+		wantErr string
+	}{
+		{name: "gzip body", body: compressed, want: "ok"},
+		{name: "empty body", body: nil},
+		{name: "corrupt body", body: []byte("not gzip"), wantErr: "failed to decompress response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{}
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type":     {"application/json"},
+					"Content-Encoding": {"gzip"},
+				},
+				Body: io.NopCloser(bytes.NewReader(tt.body)),
+			}
+
+			var result Message
+			err := client.handleResponse(resp, &result)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("handleResponse() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("handleResponse() error = %v", err)
+			}
+			if result.Message != tt.want {
+				t.Errorf("message = %q, want %q", result.Message, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsEmptyListError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "user has none", err: &HTTPError{StatusCode: http.StatusNotFound, Details: "Unable to find any deployed ranges that you own!"}, want: true},
+		{name: "admin sees none", err: &HTTPError{StatusCode: http.StatusNotFound, Details: "No range blueprints found!"}, want: true},
+		{name: "wrapped", err: fmt.Errorf("failed to list ranges: %w", &HTTPError{StatusCode: http.StatusNotFound, Details: "No jobs found!"}), want: true},
+		{name: "case differs", err: &HTTPError{StatusCode: http.StatusNotFound, Details: "UNABLE TO FIND ANY jobs"}, want: true},
+		{name: "routing miss", err: &HTTPError{StatusCode: http.StatusNotFound, Details: "Not Found"}},
+		{name: "no detail", err: &HTTPError{StatusCode: http.StatusNotFound}},
+		{name: "one item missing", err: &HTTPError{StatusCode: http.StatusNotFound, Details: "Range 5 not found!"}},
+		{name: "structured detail", err: &HTTPError{StatusCode: http.StatusNotFound, Details: []interface{}{"Unable to find any ranges"}}},
+		{name: "other status", err: &HTTPError{StatusCode: http.StatusBadRequest, Details: "Unable to find any deployed ranges that you own!"}},
+		{name: "not an HTTP error", err: errors.New("No ranges found!")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lenient := &Client{config: &config.Config{}}
+			if got := lenient.isEmptyListError(tt.err); got != tt.want {
+				t.Errorf("isEmptyListError() = %v, want %v", got, tt.want)
+			}
+
+			strict := &Client{config: &config.Config{Strict404: true}}
+			if strict.isEmptyListError(tt.err) {
+				t.Error("isEmptyListError() with Strict404 = true, want false")
+			}
+		})
+	}
+}
+
+func TestListRangesEmpty404(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		strict404 bool
+		wantErr   bool
+	}{
+		{name: "no ranges", body: `{"detail":"Unable to find any deployed ranges that you own!"}`},
+		{name: "admin no ranges", body: `{"detail":"No deployed ranges found!"}`},
+		{name: "wrong API URL", body: `{"detail":"Not Found"}`, wantErr: true},
+		{name: "strict", body: `{"detail":"Unable to find any deployed ranges that you own!"}`, strict404: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				testutil.RespondJSON(w, http.StatusNotFound, tt.body)
+			}))
+			t.Cleanup(server.Close)
+
+			cfg := testutil.NewConfig(t, server.URL)
+			cfg.Strict404 = tt.strict404
+
+			ranges, err := New(cfg).ListRanges()
+			if tt.wantErr {
+				if !errors.Is(err, ErrNotFound) {
+					t.Fatalf("ListRanges() error = %v, want ErrNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListRanges() error = %v", err)
+			}
+			if ranges == nil || len(ranges) != 0 {
+				t.Errorf("ListRanges() = %v, want an empty list", ranges)
+			}
+		})
+	}
+}
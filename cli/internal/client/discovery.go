@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+const (
+	discoveryPath     = "/.well-known/openlabs"
+	discoveryCacheTTL = time.Hour
+	discoveryFileName = "discovery.json"
+)
+
+// discoveryDocument is served by gateways that route clients to a regional API.
+type discoveryDocument struct {
+	APIURL string `json:"api_url"`
+}
+
+type discoveryCacheEntry struct {
+	APIURL    string    `json:"api_url"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// discover resolves the configured API URL through its discovery document, if it has one, and
+// points the client at the advertised API base. URLs without a discovery document are used as is.
+// Results are cached on disk for discoveryCacheTTL.
+func (c *Client) discover() error {
+	c.discoverOnce.Do(func() {
+		if c.config.NoDiscovery || c.baseURL == "" {
+			return
+		}
+
+		configured := strings.TrimRight(c.baseURL, "/")
+		cache := loadDiscoveryCache()
+
+		if entry, ok := cache[configured]; ok && time.Since(entry.FetchedAt) < discoveryCacheTTL {
+			c.setDiscoveredBase(configured, entry.APIURL)
+			return
+		}
+
+		resolved, err := c.fetchDiscoveryDocument(configured)
+		if err != nil {
+			c.discoverErr = err
+			return
+		}
+
+		cache[configured] = discoveryCacheEntry{APIURL: resolved, FetchedAt: time.Now()}
+		saveDiscoveryCache(cache)
+
+		c.setDiscoveredBase(configured, resolved)
+	})
+
+	return c.discoverErr
+}
+
+func (c *Client) setDiscoveredBase(configured, resolved string) {
+	if resolved != configured {
+		logger.Debug("Discovery for %s resolved API base to %s", configured, resolved)
+	}
+	c.baseURL = resolved
+}
+
+// fetchDiscoveryDocument returns the API base advertised by base, or base itself when it does not
+// serve a discovery document.
+func (c *Client) fetchDiscoveryDocument(base string) (string, error) {
+	req, err := http.NewRequestWithContext(withoutCredentials(context.Background()), "GET", base+discoveryPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || checkJSONContentType(resp.Header.Get("Content-Type")) != nil {
+		logger.Debug("No discovery document at %s (status %d)", base, resp.StatusCode)
+		return base, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read discovery document: %w", err)
+	}
+
+	var doc discoveryDocument
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return "", fmt.Errorf("invalid discovery document from %s: %w", base, err)
+	}
+
+	resolved := strings.TrimRight(doc.APIURL, "/")
+	parsed, err := url.Parse(resolved)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("discovery at %s returned an invalid API base %q (use --no-discovery to skip discovery)", base, doc.APIURL)
+	}
+
+	return resolved, nil
+}
+
+func discoveryCachePath() (string, error) {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), discoveryFileName), nil
+}
+
+// loadDiscoveryCache returns the cached discovery results, or an empty cache if none can be read.
+func loadDiscoveryCache() map[string]discoveryCacheEntry {
+	cache := make(map[string]discoveryCacheEntry)
+
+	path, err := discoveryCachePath()
+	if err != nil {
+		return cache
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+
+	if err := json.Unmarshal(data, &cache); err != nil {
+		logger.Debug("Ignoring unreadable discovery cache: %v", err)
+		return make(map[string]discoveryCacheEntry)
+	}
+
+	return cache
+}
+
+// saveDiscoveryCache writes the discovery cache. Failures only cost a repeat lookup, so they are logged.
+func saveDiscoveryCache(cache map[string]discoveryCacheEntry) {
+	path, err := discoveryCachePath()
+	if err != nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		logger.Debug("Failed to create discovery cache directory: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		logger.Debug("Failed to write discovery cache: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"encoding/json"
+)
+
+// Endpoints return one of two response shapes:
+//
+//	bare:      [...] or {...}           the result itself, used by every existing endpoint
+//	enveloped: {"data": ..., "meta": ...}  the result under "data", with optional sibling metadata
+//
+// makeEnvelopedRequest accepts either shape, so methods for endpoints that may be enveloped opt in
+// per call and the bare-body endpoints are not affected.
+func (c *Client) makeEnvelopedRequest(method, path string, body interface{}, result interface{}) error {
+	if result == nil {
+		return c.makeRequest(method, path, body, nil)
+	}
+	return c.makeRequest(method, path, body, &envelope{target: result})
+}
+
+// envelopeKeys are the only top-level keys an enveloped response may have. An object with any other
+// key is treated as a bare body that happens to contain a "data" field.
+var envelopeKeys = map[string]bool{
+	"data":  true,
+	"meta":  true,
+	"links": true,
+}
+
+// envelope decodes a response into target, unwrapping the "data" member of an enveloped body.
+type envelope struct {
+	target interface{}
+}
+
+func (e *envelope) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err == nil {
+		if inner, ok := fields["data"]; ok && isEnvelope(fields) {
+			return json.Unmarshal(inner, e.target)
+		}
+	}
+
+	return json.Unmarshal(data, e.target)
+}
+
+func isEnvelope(fields map[string]json.RawMessage) bool {
+	for key := range fields {
+		if !envelopeKeys[key] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HTTPError is an error response from the API. It matches the status sentinels below with errors.Is,
+// so callers can test for, say, ErrNotFound without inspecting the status code.
+type HTTPError struct {
+	StatusCode int
+	Message    string
+	Details    interface{}
+}
+
+// ErrNotSupported is returned by methods for optional endpoints that the server does not provide.
+var ErrNotSupported = errors.New("not supported by this server")
+
+// Sentinels for the API's error statuses. Errors returned by the client wrap an *HTTPError, which
+// errors.Is matches against the sentinel for its status code.
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrInvalid      = errors.New("unprocessable request")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+)
+
+var statusErrors = map[int]error{
+	http.StatusBadRequest:          ErrBadRequest,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusConflict:            ErrConflict,
+	http.StatusUnprocessableEntity: ErrInvalid,
+	http.StatusTooManyRequests:     ErrRateLimited,
+}
+
+func (e *HTTPError) Error() string {
+	if e.Details != nil {
+		return fmt.Sprintf("HTTP %d: %s - %v", e.StatusCode, e.Message, e.Details)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// Is reports whether target is the sentinel for the error's status code. Every 5xx status matches
+// ErrServer.
+func (e *HTTPError) Is(target error) bool {
+	if target == ErrServer {
+		return e.StatusCode >= 500
+	}
+	sentinel, ok := statusErrors[e.StatusCode]
+	return ok && sentinel == target
+}
+
+// isNotSupported reports whether err indicates that the requested endpoint does not exist on the server.
+func isNotSupported(err error) bool {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+
+	switch httpErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// isMissingRoute is isNotSupported for endpoints whose own 404s carry a message, such as "Blueprint
+// not found". Only a 404 with the framework's bare "Not Found" detail, or none, means the server has
+// no such route; other 404s are left for the caller to report as not found.
+func isMissingRoute(err error) bool {
+	if !isNotSupported(err) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	errors.As(err, &httpErr)
+	if httpErr.StatusCode != http.StatusNotFound || httpErr.Details == nil {
+		return true
+	}
+	detail, ok := httpErr.Details.(string)
+	return ok && strings.EqualFold(detail, "Not Found")
+}
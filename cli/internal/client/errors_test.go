@@ -0,0 +1,110 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+var sentinels = []error{
+	ErrBadRequest,
+	ErrUnauthorized,
+	ErrForbidden,
+	ErrNotFound,
+	ErrConflict,
+	ErrInvalid,
+	ErrRateLimited,
+	ErrServer,
+}
+
+func TestHTTPErrorIs(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{status: http.StatusBadRequest, want: ErrBadRequest},
+		{status: http.StatusUnauthorized, want: ErrUnauthorized},
+		{status: http.StatusForbidden, want: ErrForbidden},
+		{status: http.StatusNotFound, want: ErrNotFound},
+		{status: http.StatusConflict, want: ErrConflict},
+		{status: http.StatusUnprocessableEntity, want: ErrInvalid},
+		{status: http.StatusTooManyRequests, want: ErrRateLimited},
+		{status: http.StatusInternalServerError, want: ErrServer},
+		{status: http.StatusNotImplemented, want: ErrServer},
+		{status: http.StatusBadGateway, want: ErrServer},
+		{status: http.StatusServiceUnavailable, want: ErrServer},
+		{status: http.StatusGatewayTimeout, want: ErrServer},
+		{status: 599, want: ErrServer},
+		{status: http.StatusMethodNotAllowed},
+		{status: http.StatusTeapot},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.status), func(t *testing.T) {
+			// Callers see the HTTPError wrapped with context
+			err := fmt.Errorf("failed to get range: %w", &HTTPError{StatusCode: tt.status, Message: http.StatusText(tt.status)})
+
+			for _, sentinel := range sentinels {
+				if got, want := errors.Is(err, sentinel), sentinel == tt.want; got != want {
+					t.Errorf("errors.Is(HTTP %d, %v) = %v, want %v", tt.status, sentinel, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIsNotSupported(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "404", err: &HTTPError{StatusCode: http.StatusNotFound}, want: true},
+		{name: "405", err: &HTTPError{StatusCode: http.StatusMethodNotAllowed}, want: true},
+		{name: "501", err: &HTTPError{StatusCode: http.StatusNotImplemented}, want: true},
+		{name: "wrapped 404", err: fmt.Errorf("failed to list regions: %w", &HTTPError{StatusCode: http.StatusNotFound}), want: true},
+		{name: "400", err: &HTTPError{StatusCode: http.StatusBadRequest}},
+		{name: "422", err: &HTTPError{StatusCode: http.StatusUnprocessableEntity}},
+		{name: "500", err: &HTTPError{StatusCode: http.StatusInternalServerError}},
+		{name: "503", err: &HTTPError{StatusCode: http.StatusServiceUnavailable}},
+		{name: "ErrNotSupported itself", err: ErrNotSupported},
+		{name: "ErrNotFound sentinel", err: ErrNotFound},
+		{name: "network error", err: errors.New("connection refused")},
+		{name: "nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNotSupported(tt.err); got != tt.want {
+				t.Errorf("isNotSupported(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMissingRoute(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "404 without detail", err: &HTTPError{StatusCode: http.StatusNotFound}, want: true},
+		{name: "404 from routing", err: &HTTPError{StatusCode: http.StatusNotFound, Details: "Not Found"}, want: true},
+		{name: "wrapped 404 from routing", err: fmt.Errorf("failed: %w", &HTTPError{StatusCode: http.StatusNotFound, Details: "Not Found"}), want: true},
+		{name: "405", err: &HTTPError{StatusCode: http.StatusMethodNotAllowed, Details: "Method Not Allowed"}, want: true},
+		{name: "501", err: &HTTPError{StatusCode: http.StatusNotImplemented}, want: true},
+		{name: "404 from the endpoint", err: &HTTPError{StatusCode: http.StatusNotFound, Details: "Blueprint range with ID: 7 not found!"}},
+		{name: "404 with structured detail", err: &HTTPError{StatusCode: http.StatusNotFound, Details: map[string]interface{}{"msg": "missing"}}},
+		{name: "422", err: &HTTPError{StatusCode: http.StatusUnprocessableEntity}},
+		{name: "network error", err: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isMissingRoute(tt.err); got != tt.want {
+				t.Errorf("isMissingRoute(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+// newTestClient returns a client for a fake API served by handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	return New(testutil.FakeAPI(t, handler))
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+// longPollWait is how long the server is asked to hold a job status request open.
+const longPollWait = 30 * time.Second
+
+// maxQuickLongPolls is how many long-polls in a row may come back at once with an unchanged status
+// before the server is taken to ignore the wait parameter.
+const maxQuickLongPolls = 3
+
+// GetJobLongPoll fetches a job, asking the server to hold the request for up to wait while the job's
+// status is still lastStatus. A server without long-polling ignores the parameters and answers at
+// once, like GetJob.
+func (c *Client) GetJobLongPoll(ctx context.Context, identifier, lastStatus string, wait time.Duration) (*Job, error) {
+	var job Job
+	path := fmt.Sprintf("/api/v1/jobs/%s?wait=%d&status=%s", identifier, int(wait.Seconds()), url.QueryEscape(lastStatus))
+	if err := c.makeRequestContext(ctx, "GET", path, nil, &job, nil); err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", identifier, err)
+	}
+	return &job, nil
+}
+
+func (c *Client) getJobContext(ctx context.Context, identifier string) (*Job, error) {
+	var job Job
+	path := fmt.Sprintf("/api/v1/jobs/%s", identifier)
+	if err := c.makeRequestContext(ctx, "GET", path, nil, &job, nil); err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", identifier, err)
+	}
+	return &job, nil
+}
+
+// JobPoller follows a job's status. It long-polls when the server supports it and otherwise checks
+// at a fixed interval, so long jobs need far fewer requests on servers that can hold them open.
+type JobPoller struct {
+	client       *Client
+	jobID        string
+	interval     time.Duration
+	longPoll     bool
+	maxWait      time.Duration
+	quickReturns int
+	last         *Job
+}
+
+// NewJobPoller returns a poller for jobID that checks at least interval apart when it cannot
+// long-poll.
+func (c *Client) NewJobPoller(jobID string, interval time.Duration) *JobPoller {
+	return &JobPoller{
+		client:   c,
+		jobID:    jobID,
+		interval: interval,
+		longPoll: true,
+		maxWait:  longPollWait,
+	}
+}
+
+// LimitWait caps how long each long-poll may be held open, for callers that want to refresh
+// other information between checks more often.
+func (p *JobPoller) LimitWait(d time.Duration) {
+	if d < p.maxWait {
+		p.maxWait = d
+	}
+}
+
+// DisableLongPoll makes the poller check at its fixed interval, for callers that need to do other
+// work between checks.
+func (p *JobPoller) DisableLongPoll() {
+	p.longPoll = false
+}
+
+// Next returns the job's current state. The first call returns at once; later calls wait for a
+// status change or until the long-poll or interval ends, whichever the server allows. ctx cancels a
+// wait in progress.
+func (p *JobPoller) Next(ctx context.Context) (*Job, error) {
+	if p.last == nil {
+		return p.record(p.client.getJobContext(ctx, p.jobID))
+	}
+
+	wait := p.longPollWait(ctx)
+	if wait == 0 {
+		if err := sleepContext(ctx, p.interval); err != nil {
+			return nil, err
+		}
+		return p.record(p.client.getJobContext(ctx, p.jobID))
+	}
+
+	start := time.Now()
+	job, err := p.client.GetJobLongPoll(ctx, p.jobID, p.last.Status, wait)
+	if err != nil {
+		if errors.Is(err, ErrBadRequest) || errors.Is(err, ErrInvalid) {
+			logger.Debug("Server rejected job long-polling, polling every %v instead: %v", p.interval, err)
+			p.longPoll = false
+			return p.Next(ctx)
+		}
+		return nil, err
+	}
+
+	// An unchanged status returned early means the server ignored the wait or closed the long-poll;
+	// pace the next check like fixed-interval polling
+	if elapsed := time.Since(start); job.Status == p.last.Status && elapsed < p.interval {
+		p.quickReturns++
+		if p.quickReturns >= maxQuickLongPolls {
+			logger.Debug("Server does not hold job requests open, polling every %v instead", p.interval)
+			p.longPoll = false
+		}
+		if err := sleepContext(ctx, p.interval-elapsed); err != nil {
+			return nil, err
+		}
+	} else {
+		p.quickReturns = 0
+	}
+
+	return p.record(job, nil)
+}
+
+func (p *JobPoller) record(job *Job, err error) (*Job, error) {
+	if err != nil {
+		return nil, err
+	}
+	p.last = job
+	return job, nil
+}
+
+// longPollWait returns how long the next request may ask the server to wait, or 0 to poll normally.
+// The wait stays under the HTTP client timeout and the context deadline.
+func (p *JobPoller) longPollWait(ctx context.Context) time.Duration {
+	if !p.longPoll {
+		return 0
+	}
+
+	wait := p.maxWait
+	if timeout := p.client.httpClient.Timeout; timeout > 0 && wait > timeout-5*time.Second {
+		wait = timeout - 5*time.Second
+	}
+	if deadline, ok := ctx.Deadline(); ok && wait > time.Until(deadline) {
+		wait = time.Until(deadline)
+	}
+
+	if wait < p.interval {
+		return 0
+	}
+	return wait.Truncate(time.Second)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
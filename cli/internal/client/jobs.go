@@ -1,23 +1,69 @@
 package client
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 )
 
+// ListJobs returns the user's jobs, optionally filtered by status. Having no jobs is not an error:
+// servers report it as a 404 or as an object carrying only a message, and both become an empty slice.
 func (c *Client) ListJobs(status string) ([]Job, error) {
 	path := "/api/v1/jobs"
 	if status != "" {
 		path += "?job_status=" + status
 	}
 
-	var jobs []Job
-	if err := c.makeRequest("GET", path, nil, &jobs); err != nil {
+	var jobs jobList
+	if err := c.makeEnvelopedRequest("GET", path, nil, &jobs); err != nil {
+		if c.isEmptyListError(err) {
+			return []Job{}, nil
+		}
 		return nil, fmt.Errorf("failed to list jobs: %w", err)
 	}
 	return jobs, nil
 }
 
+// jobList decodes a job list sent as a bare array, as an object holding the jobs in "items", or as an
+// object with only a "detail" or "message" field, such as {"detail": "Unable to find any jobs that
+// you own!"}, which means no jobs.
+type jobList []Job
+
+func (l *jobList) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	if bytes.Equal(data, []byte("null")) {
+		*l = jobList{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		for key := range fields {
+			if key != "items" && key != "detail" && key != "message" {
+				return fmt.Errorf("unexpected job list object with field %q", key)
+			}
+		}
+		if items, ok := fields["items"]; ok {
+			return l.UnmarshalJSON(items)
+		}
+		*l = jobList{}
+		return nil
+	}
+
+	jobs := []Job{}
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return err
+	}
+	*l = jobs
+	return nil
+}
+
 func (c *Client) GetJob(identifier string) (*Job, error) {
 	var job Job
 	path := fmt.Sprintf("/api/v1/jobs/%s", identifier)
@@ -27,39 +73,76 @@ func (c *Client) GetJob(identifier string) (*Job, error) {
 	return &job, nil
 }
 
+// CancelJob asks the server to abort a queued or running job and returns the job's resulting state.
+// It returns ErrNotSupported when the server does not allow cancelling jobs.
+func (c *Client) CancelJob(identifier string) (*Job, error) {
+	var job Job
+	path := fmt.Sprintf("/api/v1/jobs/%s/cancel", identifier)
+	if err := c.makeRequest("POST", path, nil, &job); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to cancel job %s: %w", identifier, err)
+	}
+	return &job, nil
+}
+
+// DeleteJob removes a finished job's record. It returns ErrNotSupported when the server does not
+// allow deleting job records.
+func (c *Client) DeleteJob(identifier string) error {
+	path := fmt.Sprintf("/api/v1/jobs/%s", identifier)
+	if err := c.makeRequest("DELETE", path, nil, nil); err != nil {
+		if isNotSupported(err) {
+			return ErrNotSupported
+		}
+		return fmt.Errorf("failed to delete job %s: %w", identifier, err)
+	}
+	return nil
+}
+
+// GetJobLogs returns the log lines for a job starting at offset. It returns ErrNotSupported
+// when the server does not expose job logs.
+func (c *Client) GetJobLogs(identifier string, offset int) (*JobLogs, error) {
+	var logs JobLogs
+	path := fmt.Sprintf("/api/v1/jobs/%s/logs?offset=%d", identifier, offset)
+	if err := c.makeEnvelopedRequest("GET", path, nil, &logs); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to get logs for job %s: %w", identifier, err)
+	}
+	return &logs, nil
+}
+
+// WaitForJobCompletion waits until a job completes or fails, long-polling when the server supports it.
 func (c *Client) WaitForJobCompletion(jobID string, timeout time.Duration) (*Job, error) {
-	deadline := time.Now().Add(timeout)
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	poller := c.NewJobPoller(jobID, 2*time.Second)
 
 	for {
-		select {
-		case <-ticker.C:
-			job, err := c.GetJob(jobID)
-			if err != nil {
-				return nil, err
+		job, err := poller.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("job timeout after %v", timeout)
 			}
+			return nil, err
+		}
 
-			switch job.Status {
-			case "complete":
-				return job, nil
-			case "failed":
-				errorMsg := "job failed"
-				if job.ErrorMessage != "" {
-					errorMsg = fmt.Sprintf("job failed: %s", job.ErrorMessage)
-				}
-				return job, fmt.Errorf("%s", errorMsg)
-			case "queued", "in_progress":
-				if time.Now().After(deadline) {
-					return job, fmt.Errorf("job timeout after %v", timeout)
-				}
-				continue
-			default:
-				return job, fmt.Errorf("unknown job status: %s", job.Status)
+		switch job.Status {
+		case "complete":
+			return job, nil
+		case "failed":
+			errorMsg := "job failed"
+			if job.ErrorMessage != "" {
+				errorMsg = fmt.Sprintf("job failed: %s", job.ErrorMessage)
 			}
-
-		case <-time.After(timeout):
-			return nil, fmt.Errorf("job timeout after %v", timeout)
+			return job, fmt.Errorf("%s", errorMsg)
+		case "queued", "in_progress":
+			continue
+		default:
+			return job, fmt.Errorf("unknown job status: %s", job.Status)
 		}
 	}
 }
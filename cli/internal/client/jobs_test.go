@@ -0,0 +1,89 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestJobListUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantIDs []int
+		wantErr bool
+	}{
+		{name: "bare array", data: `[{"id":1,"status":"complete"},{"id":2,"status":"queued"}]`, wantIDs: []int{1, 2}},
+		{name: "empty array", data: `[]`, wantIDs: []int{}},
+		{name: "items object", data: `{"items":[{"id":3,"status":"in_progress"}]}`, wantIDs: []int{3}},
+		{name: "items object with null items", data: `{"items":null}`, wantIDs: []int{}},
+		{name: "empty object", data: `{}`, wantIDs: []int{}},
+		{name: "no jobs detail", data: `{"detail":"Unable to find any jobs that you own!"}`, wantIDs: []int{}},
+		{name: "no jobs message", data: `{"message":"No jobs found!"}`, wantIDs: []int{}},
+		{name: "null", data: `null`, wantIDs: []int{}},
+		{name: "null with whitespace", data: " null\n", wantIDs: []int{}},
+		{name: "unexpected object", data: `{"id":1,"status":"complete"}`, wantErr: true},
+		{name: "wrong type", data: `"jobs"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jobs jobList
+			err := json.Unmarshal([]byte(tt.data), &jobs)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal() = %v, want an error", jobs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			// The no-jobs cases give an empty slice rather than nil, so JSON output is [] not null
+			if jobs == nil {
+				t.Fatal("Unmarshal() gave a nil slice")
+			}
+			if len(jobs) != len(tt.wantIDs) {
+				t.Fatalf("got %d jobs, want %d", len(jobs), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				if jobs[i].ID != id {
+					t.Errorf("job %d ID = %d, want %d", i, jobs[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestListJobsResponseShapes(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   int
+	}{
+		{name: "bare array", status: http.StatusOK, body: `[{"id":1},{"id":2}]`, want: 2},
+		{name: "enveloped", status: http.StatusOK, body: `{"data":[{"id":1}],"meta":{"total":1}}`, want: 1},
+		{name: "no jobs message", status: http.StatusOK, body: `{"detail":"Unable to find any jobs that you own!"}`, want: 0},
+		{name: "no jobs 404", status: http.StatusNotFound, body: `{"detail":"Unable to find any jobs that you own!"}`, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				testutil.RespondJSON(w, tt.status, tt.body)
+			})
+
+			jobs, err := apiClient.ListJobs("")
+			if err != nil {
+				t.Fatalf("ListJobs() error = %v", err)
+			}
+			if jobs == nil || len(jobs) != tt.want {
+				t.Errorf("ListJobs() = %v, want %d jobs", jobs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ListOptions asks a list endpoint for server-side paging and sorting. Servers that do not support
+// them ignore the query parameters and return the whole list unsorted, so callers still sort locally.
+type ListOptions struct {
+	// PageSize is the number of items per page; 0 leaves paging to the server
+	PageSize int
+
+	// Sort is a field name, prefixed with "-" for descending order
+	Sort string
+
+	// All requests every page instead of only the first
+	All bool
+}
+
+func (o ListOptions) query(page int) string {
+	values := url.Values{}
+	if o.PageSize > 0 {
+		values.Set("page_size", strconv.Itoa(o.PageSize))
+		if page > 1 {
+			values.Set("page", strconv.Itoa(page))
+		}
+	}
+	if o.Sort != "" {
+		values.Set("sort", o.Sort)
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+	return "?" + values.Encode()
+}
+
+// collectPages requests the pages of a list endpoint and hands each page's JSON array to add, which
+// decodes it and returns its length. Paging stops after the first page unless opts.All is set, at a
+// short page, or when the server evidently ignores paging by returning the same page again.
+func (c *Client) collectPages(path string, opts ListOptions, add func(page json.RawMessage) (int, error)) error {
+	var previous json.RawMessage
+
+	for page := 1; ; page++ {
+		var raw json.RawMessage
+		if err := c.makeEnvelopedRequest("GET", path+opts.query(page), nil, &raw); err != nil {
+			// Some servers answer a page past the end with their "nothing found" 404
+			if page > 1 && c.isEmptyListError(err) {
+				return nil
+			}
+			return err
+		}
+
+		if previous != nil && bytes.Equal(raw, previous) {
+			return nil
+		}
+
+		n, err := add(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if !opts.All || opts.PageSize <= 0 || n != opts.PageSize {
+			return nil
+		}
+		previous = raw
+	}
+}
@@ -0,0 +1,366 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+// Middleware wraps a RoundTripper with one cross-cutting concern, such as authentication or retries.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// chain wraps base with middlewares so that the first middleware sees each request first.
+func chain(base http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+	return base
+}
+
+const (
+	maxRetries        = 2
+	retryBaseDelay    = 500 * time.Millisecond
+	maxRetryAfter     = 10 * time.Second
+	requestsPerSecond = 20
+)
+
+// defaultMiddlewares returns the chain every Client sends requests through. Credentials are added
+// once, outside the retry loop; each attempt is rate limited and logged separately.
+func defaultMiddlewares(cfg *config.Config) []Middleware {
+	return []Middleware{
+		authMiddleware(cfg),
+		csrfMiddleware(cfg),
+		retryMiddleware(maxRetries, retryBaseDelay),
+		rateLimitMiddleware(requestsPerSecond),
+		loggingMiddleware(),
+	}
+}
+
+type skipAuthKey struct{}
+
+// withoutCredentials marks a request context so the auth middleware leaves the request untouched,
+// for requests such as discovery that may go to a host other than the API.
+func withoutCredentials(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipAuthKey{}, true)
+}
+
+// authMiddleware attaches the token and encryption key cookies from cfg to each request.
+func authMiddleware(cfg *config.Config) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if skip, _ := req.Context().Value(skipAuthKey{}).(bool); skip {
+				return next.RoundTrip(req)
+			}
+
+			// A RoundTripper must not modify the caller's request
+			req = req.Clone(req.Context())
+			addAuthenticationCookies(req, cfg)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// csrfHeader carries the CSRF token on state-changing requests.
+const csrfHeader = "X-CSRF-Token"
+
+// csrfCookieNames are the cookies CSRF-protected servers commonly issue their token in.
+var csrfCookieNames = []string{"csrf_token", "csrftoken", "csrf_access_token", "XSRF-TOKEN"}
+
+// csrfMu guards the CSRF fields of configs shared by clients sending requests concurrently.
+var csrfMu sync.Mutex
+
+// csrfMiddleware supports servers that protect cookie-authenticated requests against CSRF. It
+// remembers the token a server sends in an X-CSRF-Token header or a CSRF cookie and echoes it, as the
+// header and as the cookie it arrived in, on every request that is not GET, HEAD, or OPTIONS. Servers
+// that never send a token are unaffected. A changed token is kept in cfg; the command saves it with
+// the session credentials once it finishes.
+func csrfMiddleware(cfg *config.Config) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if skip, _ := req.Context().Value(skipAuthKey{}).(bool); skip {
+				return next.RoundTrip(req)
+			}
+
+			csrfMu.Lock()
+			token, cookieName := cfg.CSRFToken, cfg.CSRFCookie
+			csrfMu.Unlock()
+
+			if token != "" && !isIdempotent(req.Method) {
+				req = req.Clone(req.Context())
+				req.Header.Set(csrfHeader, token)
+				if cookieName != "" {
+					req.AddCookie(&http.Cookie{Name: cookieName, Value: token})
+				}
+				logger.Debug("Added CSRF token")
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+
+			if newToken, newCookie, ok := csrfTokenFromResponse(resp); ok && (newToken != token || newCookie != cookieName) {
+				csrfMu.Lock()
+				cfg.SetCSRFToken(newToken, newCookie)
+				csrfMu.Unlock()
+				logger.Debug("Captured CSRF token")
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+// csrfTokenFromResponse returns the CSRF token a response carries and the cookie it came in, which
+// is empty when the token came in the X-CSRF-Token header.
+func csrfTokenFromResponse(resp *http.Response) (token, cookieName string, ok bool) {
+	for _, cookie := range resp.Cookies() {
+		for _, name := range csrfCookieNames {
+			if cookie.Name == name && cookie.Value != "" {
+				return cookie.Value, cookie.Name, true
+			}
+		}
+	}
+
+	if token := resp.Header.Get(csrfHeader); token != "" {
+		return token, "", true
+	}
+
+	return "", "", false
+}
+
+// loggingMiddleware logs each attempt: a one-line summary at info level, cookie names at debug level,
+// and request and response bodies at trace level with credential fields redacted. Cookie values
+// are never logged.
+func loggingMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			cookies := req.Cookies()
+			names := make([]string, 0, len(cookies))
+			for _, cookie := range cookies {
+				names = append(names, cookie.Name)
+			}
+			logger.Debug("Making request to %s %s (cookies: %v)", req.Method, req.URL.Redacted(), names)
+
+			if logger.Enabled(logger.LevelTrace) && req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					data, _ := io.ReadAll(body)
+					body.Close()
+					logger.Trace("Request body: %s", redactBody(data))
+				}
+			}
+
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				logger.Info("%s %s failed after %s: %v", req.Method, req.URL.Redacted(), time.Since(start).Round(time.Millisecond), err)
+				return nil, err
+			}
+
+			logger.Info("%s %s -> %s (%s)", req.Method, req.URL.Redacted(), resp.Status, time.Since(start).Round(time.Millisecond))
+
+			if logger.Enabled(logger.LevelTrace) {
+				traceResponseBody(resp)
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+// maxTracedBody limits how much of a body is logged at trace level.
+const maxTracedBody = 4096
+
+// traceResponseBody logs the response body and replaces it with an in-memory copy so the caller can
+// still read it.
+func traceResponseBody(resp *http.Response) {
+	if !resp.Uncompressed && strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		logger.Trace("Response body: (gzip-encoded)")
+		return
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		logger.Trace("Response body: (read failed: %v)", err)
+		return
+	}
+
+	logger.Trace("Response body: %s", redactBody(data))
+}
+
+// sensitiveKeyParts mark JSON keys whose values are replaced when bodies are logged.
+var sensitiveKeyParts = []string{"password", "secret", "token", "key", "credential"}
+
+// redactBody returns body for logging with the values of credential-like JSON fields replaced.
+// Bodies that are not JSON are logged as is; long bodies are truncated.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return "(empty)"
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err == nil {
+		if redacted, err := json.Marshal(redactValue(value)); err == nil {
+			body = redacted
+		}
+	}
+
+	if len(body) > maxTracedBody {
+		return fmt.Sprintf("%s... (%d bytes)", body[:maxTracedBody], len(body))
+	}
+	return string(body)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			// Flags such as has_credentials are safe to show; only string values carry secrets
+			if text, ok := item.(string); ok && text != "" && isSensitiveKey(key) {
+				v[key] = "[REDACTED]"
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryMiddleware retries idempotent requests that fail with a network error or a transient status
+// (429, 502, 503, 504), waiting longer after each attempt and honouring Retry-After when present.
+func retryMiddleware(retries int, baseDelay time.Duration) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !isIdempotent(req.Method) || (req.Body != nil && req.GetBody == nil) {
+				return next.RoundTrip(req)
+			}
+
+			for attempt := 0; ; attempt++ {
+				attemptReq := req
+				if attempt > 0 && req.GetBody != nil {
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					attemptReq = req.Clone(req.Context())
+					attemptReq.Body = body
+				}
+
+				resp, err := next.RoundTrip(attemptReq)
+				if attempt >= retries || !isRetryable(resp, err) {
+					return resp, err
+				}
+
+				delay := baseDelay * time.Duration(1<<attempt)
+				if resp != nil {
+					if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+						delay = retryAfter
+					}
+					resp.Body.Close()
+				}
+
+				logger.Debug("Retrying %s %s in %s (attempt %d of %d)", req.Method, req.URL.Path, delay, attempt+1, retries)
+
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(delay):
+				}
+			}
+		})
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds, capped at maxRetryAfter.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return min(time.Duration(seconds)*time.Second, maxRetryAfter), true
+}
+
+// rateLimitMiddleware spaces requests at least 1/perSecond apart, so bulk commands running requests
+// concurrently do not flood the API.
+func rateLimitMiddleware(perSecond int) Middleware {
+	interval := time.Second / time.Duration(perSecond)
+
+	var mu sync.Mutex
+	var next time.Time
+
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			now := time.Now()
+			if next.Before(now) {
+				next = now
+			}
+			wait := next.Sub(now)
+			next = next.Add(interval)
+			mu.Unlock()
+
+			if wait > 0 {
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(wait):
+				}
+			}
+
+			return rt.RoundTrip(req)
+		})
+	}
+}
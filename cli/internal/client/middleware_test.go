@@ -0,0 +1,259 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" request")
+				resp, err := next.RoundTrip(req)
+				calls = append(calls, name+" response")
+				return resp, err
+			})
+		}
+	}
+
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, "transport")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/api/v1/health/ping", nil)
+	if _, err := chain(base, record("first"), record("second"), record("third")).RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+
+	want := "first request, second request, third request, transport, third response, second response, first response"
+	if got := strings.Join(calls, ", "); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
+
+// hopEvent is a request passed from one middleware of the default chain to the next.
+type hopEvent struct {
+	hop    int
+	method string
+	at     time.Time
+	token  bool
+	csrf   bool
+}
+
+// TestDefaultMiddlewaresOrder checks the default chain runs auth, csrf, retry, rateLimit, logging,
+// identifying each middleware by what it does to the requests it passes on.
+func TestDefaultMiddlewaresOrder(t *testing.T) {
+	cfg := testutil.NewConfig(t, "http://localhost")
+	cfg.CSRFToken = "csrf-value"
+
+	var mu sync.Mutex
+	var events []hopEvent
+	middlewares := defaultMiddlewares(cfg)
+	instrumented := make([]Middleware, len(middlewares))
+	for i, middleware := range middlewares {
+		instrumented[i] = func(next http.RoundTripper) http.RoundTripper {
+			return middleware(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				_, err := req.Cookie("token")
+				mu.Lock()
+				events = append(events, hopEvent{hop: i, method: req.Method, at: time.Now(), token: err == nil, csrf: req.Header.Get(csrfHeader) != ""})
+				mu.Unlock()
+				return next.RoundTrip(req)
+			}))
+		}
+	}
+
+	// GETs fail twice with a retryable status before succeeding
+	failures := 0
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}"))}
+		if req.Method == http.MethodGet && failures < 2 {
+			failures++
+			resp.StatusCode = http.StatusServiceUnavailable
+			resp.Header.Set("Retry-After", "0")
+		}
+		return resp, nil
+	})
+	transport := chain(base, instrumented...)
+
+	send := func(method string) []hopEvent {
+		t.Helper()
+
+		mu.Lock()
+		events = nil
+		mu.Unlock()
+
+		req, _ := http.NewRequest(method, "http://localhost/api/v1/ranges", nil)
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("%s RoundTrip() error = %v", method, err)
+		}
+		resp.Body.Close()
+
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]hopEvent(nil), events...)
+	}
+
+	firstAt := func(events []hopEvent, hop int) hopEvent {
+		for _, event := range events {
+			if event.hop == hop {
+				return event
+			}
+		}
+		t.Fatalf("hop %d never passed the request on", hop)
+		return hopEvent{}
+	}
+
+	names := []string{"auth", "csrf", "retry", "rateLimit", "logging"}
+	if len(middlewares) != len(names) {
+		t.Fatalf("defaultMiddlewares() has %d middlewares, want %d (%s)", len(middlewares), len(names), strings.Join(names, ", "))
+	}
+
+	post := send(http.MethodPost)
+
+	// auth: the token cookie is on the request from the first hop on
+	for _, event := range post {
+		if !event.token {
+			t.Errorf("%s passed on a request without the token cookie, want auth first", names[event.hop])
+		}
+	}
+
+	// csrf: the header is added by the second middleware
+	if firstAt(post, 0).csrf || !firstAt(post, 1).csrf {
+		t.Errorf("CSRF header added at the wrong hop, want csrf second")
+	}
+
+	// retry: the GET is sent once by the outer middlewares and three times from the third one on
+	counts := make([]int, len(names))
+	for _, event := range send(http.MethodGet) {
+		counts[event.hop]++
+	}
+	if got, want := fmt.Sprint(counts), fmt.Sprint([]int{1, 1, 3, 3, 3}); got != want {
+		t.Errorf("attempts per hop = %s, want %s (retry third)", got, want)
+	}
+
+	// rateLimit: a request sent right after another is held back by the fourth middleware only
+	second := send(http.MethodPost)
+	if wait := firstAt(second, 3).at.Sub(firstAt(second, 2).at); wait < time.Second/requestsPerSecond/2 {
+		t.Errorf("request passed the fourth hop after %s, want it rate limited there", wait)
+	}
+	if wait := firstAt(second, 4).at.Sub(firstAt(second, 3).at); wait >= time.Second/requestsPerSecond/2 {
+		t.Errorf("request held %s at the last hop, want logging last", wait)
+	}
+}
+
+// newCSRFServer returns a handler that issues a CSRF token in a cookie on GET and rejects POSTs that
+// do not echo it in both the header and the cookie.
+func newCSRFServer(token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			http.SetCookie(w, &http.Cookie{Name: "csrf_token", Value: token, Path: "/"})
+			testutil.RespondJSON(w, http.StatusOK, `{"message":"ok"}`)
+			return
+		}
+
+		cookie, err := r.Cookie("csrf_token")
+		if err != nil || cookie.Value != token || r.Header.Get(csrfHeader) != token {
+			testutil.RespondJSON(w, http.StatusForbidden, `{"detail":"CSRF token missing or incorrect"}`)
+			return
+		}
+		testutil.RespondJSON(w, http.StatusOK, `{"message":"created"}`)
+	}
+}
+
+func TestCSRFTokenKeptInMemory(t *testing.T) {
+	server := httptest.NewServer(newCSRFServer("csrf-1"))
+	t.Cleanup(server.Close)
+
+	cfg := testutil.NewConfig(t, server.URL)
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiClient := New(cfg)
+	var result Message
+	if err := apiClient.makeRequest(http.MethodPost, "/api/v1/blueprints", nil, &result); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("POST before the token was issued: error = %v, want ErrForbidden", err)
+	}
+	if err := apiClient.makeRequest(http.MethodGet, "/api/v1/users/me", nil, &result); err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	if err := apiClient.makeRequest(http.MethodPost, "/api/v1/blueprints", nil, &result); err != nil {
+		t.Fatalf("POST with the captured token: error = %v", err)
+	}
+	if cfg.CSRFToken != "csrf-1" || cfg.CSRFCookie != "csrf_token" {
+		t.Errorf("config token = %q in %q, want %q in %q", cfg.CSRFToken, cfg.CSRFCookie, "csrf-1", "csrf_token")
+	}
+
+	after, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Fatal("config file written while sending requests, want the token kept in memory")
+	}
+
+	if err := cfg.SaveCSRFToken(); err != nil {
+		t.Fatalf("SaveCSRFToken() error = %v", err)
+	}
+	saved, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.CSRFToken != "csrf-1" || saved.CSRFCookie != "csrf_token" {
+		t.Errorf("saved token = %q in %q, want %q in %q", saved.CSRFToken, saved.CSRFCookie, "csrf-1", "csrf_token")
+	}
+
+	// A loaded token is sent without another GET
+	saved.NoDiscovery, saved.NoCache = true, true
+	reloaded := New(saved)
+	if err := reloaded.makeRequest(http.MethodPost, "/api/v1/blueprints", nil, &result); err != nil {
+		t.Fatalf("POST with the saved token: error = %v", err)
+	}
+}
+
+func TestSaveCSRFTokenWithoutSession(t *testing.T) {
+	server := httptest.NewServer(newCSRFServer("csrf-1"))
+	t.Cleanup(server.Close)
+
+	cfg := testutil.NewConfig(t, server.URL)
+	cfg.AuthToken = ""
+
+	var result Message
+	if err := New(cfg).makeRequest(http.MethodGet, "/api/v1/health/ping", nil, &result); err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	if err := cfg.SaveCSRFToken(); err != nil {
+		t.Fatalf("SaveCSRFToken() error = %v", err)
+	}
+
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		t.Errorf("config file saved without a session (stat error: %v)", err)
+	}
+}
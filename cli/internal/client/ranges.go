@@ -1,11 +1,30 @@
 package client
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
 
 func (c *Client) ListRanges() ([]DeployedRangeHeader, error) {
-	var ranges []DeployedRangeHeader
-	if err := c.makeRequest("GET", "/api/v1/ranges", nil, &ranges); err != nil {
-		if httpErr, ok := err.(*HTTPError); ok && httpErr.StatusCode == 404 {
+	return c.ListRangesWithOptions(ListOptions{})
+}
+
+// ListRangesWithOptions lists ranges with server-side paging and sorting where the server supports it.
+func (c *Client) ListRangesWithOptions(opts ListOptions) ([]DeployedRangeHeader, error) {
+	ranges := []DeployedRangeHeader{}
+	err := c.collectPages("/api/v1/ranges", opts, func(data json.RawMessage) (int, error) {
+		var page []DeployedRangeHeader
+		if err := json.Unmarshal(data, &page); err != nil {
+			return 0, err
+		}
+		ranges = append(ranges, page...)
+		return len(page), nil
+	})
+	if err != nil {
+		if c.isEmptyListError(err) {
 			return []DeployedRangeHeader{}, nil
 		}
 		return nil, fmt.Errorf("failed to list ranges: %w", err)
@@ -14,9 +33,14 @@ func (c *Client) ListRanges() ([]DeployedRangeHeader, error) {
 }
 
 func (c *Client) GetRange(id int) (*DeployedRange, error) {
+	return c.GetRangeContext(context.Background(), id)
+}
+
+// GetRangeContext is GetRange with a context that can cancel the request.
+func (c *Client) GetRangeContext(ctx context.Context, id int) (*DeployedRange, error) {
 	var rangeData DeployedRange
 	path := fmt.Sprintf("/api/v1/ranges/%d", id)
-	if err := c.makeRequest("GET", path, nil, &rangeData); err != nil {
+	if err := c.makeRequestContext(ctx, "GET", path, nil, &rangeData, nil); err != nil {
 		return nil, fmt.Errorf("failed to get range %d: %w", id, err)
 	}
 	return &rangeData, nil
@@ -25,7 +49,7 @@ func (c *Client) GetRange(id int) (*DeployedRange, error) {
 func (c *Client) DeployRange(request *DeployRangeRequest) (*JobSubmissionResponse, error) {
 	var response JobSubmissionResponse
 	if err := c.makeRequest("POST", "/api/v1/ranges/deploy", request, &response); err != nil {
-		return nil, fmt.Errorf("failed to deploy range: %w", err)
+		return nil, fmt.Errorf("failed to deploy range: %w", encryptionKeyError(err))
 	}
 	return &response, nil
 }
@@ -34,7 +58,7 @@ func (c *Client) DeleteRange(id int) (*JobSubmissionResponse, error) {
 	var response JobSubmissionResponse
 	path := fmt.Sprintf("/api/v1/ranges/%d", id)
 	if err := c.makeRequest("DELETE", path, nil, &response); err != nil {
-		return nil, fmt.Errorf("failed to delete range %d: %w", id, err)
+		return nil, fmt.Errorf("failed to delete range %d: %w", id, encryptionKeyError(err))
 	}
 	return &response, nil
 }
@@ -43,7 +67,136 @@ func (c *Client) GetRangeKey(id int) (*RangeKeyResponse, error) {
 	var keyResponse RangeKeyResponse
 	path := fmt.Sprintf("/api/v1/ranges/%d/key", id)
 	if err := c.makeRequest("GET", path, nil, &keyResponse); err != nil {
-		return nil, fmt.Errorf("failed to get range key for %d: %w", id, err)
+		return nil, fmt.Errorf("failed to get range key for %d: %w", id, encryptionKeyError(err))
 	}
 	return &keyResponse, nil
 }
+
+// GetRangeVNCInfo returns the VNC console address and credentials of a range deployed with VNC. It
+// returns ErrNotSupported when the server does not provide VNC access details.
+func (c *Client) GetRangeVNCInfo(id int) (*VNCInfo, error) {
+	var info VNCInfo
+	path := fmt.Sprintf("/api/v1/ranges/%d/vnc", id)
+	if err := c.makeRequest("GET", path, nil, &info); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to get VNC details for range %d: %w", id, encryptionKeyError(err))
+	}
+	return &info, nil
+}
+
+// GetRangeVPNConfig returns the client configuration for the VPN of a range deployed with VPN. It
+// returns ErrNotSupported when the server does not provide VPN configs.
+func (c *Client) GetRangeVPNConfig(id int) (*VPNConfig, error) {
+	var vpnConfig VPNConfig
+	path := fmt.Sprintf("/api/v1/ranges/%d/vpn", id)
+	if err := c.makeRequest("GET", path, nil, &vpnConfig); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to get VPN config for range %d: %w", id, encryptionKeyError(err))
+	}
+	return &vpnConfig, nil
+}
+
+// UpdateRangeLabels replaces the labels of a range. It returns ErrNotSupported when the server does
+// not support range labels.
+func (c *Client) UpdateRangeLabels(id int, labels map[string]string) error {
+	path := fmt.Sprintf("/api/v1/ranges/%d/labels", id)
+	body := map[string]map[string]string{"labels": labels}
+	if err := c.makeRequest("PUT", path, body, nil); err != nil {
+		if isNotSupported(err) {
+			return ErrNotSupported
+		}
+		return fmt.Errorf("failed to update labels for range %d: %w", id, err)
+	}
+	return nil
+}
+
+// RefreshRange asks the server to re-sync a range's stored state with its cloud resources. It returns
+// ErrNotSupported when the server has no refresh endpoint.
+func (c *Client) RefreshRange(id int) (*JobSubmissionResponse, error) {
+	var response JobSubmissionResponse
+	path := fmt.Sprintf("/api/v1/ranges/%d/refresh", id)
+	if err := c.makeRequest("POST", path, nil, &response); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to refresh range %d: %w", id, err)
+	}
+	return &response, nil
+}
+
+// RebootHost restarts a host in a deployed range. It returns ErrNotSupported when the server does not
+// support host power operations.
+func (c *Client) RebootHost(rangeID, hostID int) (*HostPowerResponse, error) {
+	return c.hostPowerAction(rangeID, hostID, "reboot")
+}
+
+// StopHost powers off a host in a deployed range. It returns ErrNotSupported when the server does not
+// support host power operations.
+func (c *Client) StopHost(rangeID, hostID int) (*HostPowerResponse, error) {
+	return c.hostPowerAction(rangeID, hostID, "stop")
+}
+
+// StartHost powers on a stopped host in a deployed range. It returns ErrNotSupported when the server
+// does not support host power operations.
+func (c *Client) StartHost(rangeID, hostID int) (*HostPowerResponse, error) {
+	return c.hostPowerAction(rangeID, hostID, "start")
+}
+
+func (c *Client) hostPowerAction(rangeID, hostID int, action string) (*HostPowerResponse, error) {
+	var response HostPowerResponse
+	path := fmt.Sprintf("/api/v1/ranges/%d/hosts/%d/%s", rangeID, hostID, action)
+	if err := c.makeRequest("POST", path, nil, &response); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to %s host %d: %w", action, hostID, err)
+	}
+	return &response, nil
+}
+
+// ScaleHost changes the disk size, in GB, of a host in a deployed range. The server applies the change
+// as a job. It returns ErrNotSupported when the server cannot scale hosts.
+func (c *Client) ScaleHost(rangeID, hostID, size int) (*JobSubmissionResponse, error) {
+	var response JobSubmissionResponse
+	path := fmt.Sprintf("/api/v1/ranges/%d/hosts/%d/scale", rangeID, hostID)
+	if err := c.makeRequest("POST", path, ScaleHostRequest{Size: size}, &response); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to scale host %d: %w", hostID, encryptionKeyError(err))
+	}
+	return &response, nil
+}
+
+// GetHostOptions returns the host settings the server accepts for a provider. It returns
+// ErrNotSupported when the server does not publish them.
+func (c *Client) GetHostOptions(provider string) (*HostOptions, error) {
+	var options HostOptions
+	path := "/api/v1/ranges/hosts/options?provider=" + url.QueryEscape(provider)
+	if err := c.cachedGet(path, hostOptionsTTL, &envelope{target: &options}); err != nil {
+		if isNotSupported(err) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to get host options for %s: %w", provider, err)
+	}
+	return &options, nil
+}
+
+// ListRegions returns the regions the server accepts for a provider. It returns ErrNotSupported
+// when the server does not publish its region list. Servers without one route the path to
+// /ranges/{range_id} and reject "regions" as an ID with a 422, which counts as unsupported too.
+func (c *Client) ListRegions(provider string) ([]string, error) {
+	var regions []string
+	path := "/api/v1/ranges/regions?provider=" + url.QueryEscape(provider)
+	if err := c.cachedGet(path, regionCacheTTL, &envelope{target: &regions}); err != nil {
+		if isNotSupported(err) || errors.Is(err, ErrInvalid) {
+			return nil, ErrNotSupported
+		}
+		return nil, fmt.Errorf("failed to list regions for %s: %w", provider, err)
+	}
+	return regions, nil
+}
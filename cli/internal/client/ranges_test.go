@@ -0,0 +1,56 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+func TestListRegions(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    []string
+		wantErr error
+	}{
+		{name: "list", status: http.StatusOK, body: `["us_east_1","eu_west_1"]`, want: []string{"us_east_1", "eu_west_1"}},
+		{name: "no endpoint", status: http.StatusNotFound, body: `{"detail":"Not Found"}`, wantErr: ErrNotSupported},
+		{name: "method not allowed", status: http.StatusMethodNotAllowed, body: `{"detail":"Method Not Allowed"}`, wantErr: ErrNotSupported},
+		{
+			name:    "path taken by the range ID route",
+			status:  http.StatusUnprocessableEntity,
+			body:    `{"detail":[{"loc":["path","range_id"],"msg":"Input should be a valid integer","type":"int_parsing"}]}`,
+			wantErr: ErrNotSupported,
+		},
+		{name: "expired session", status: http.StatusUnauthorized, body: `{"detail":"Could not validate credentials"}`, wantErr: ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/ranges/regions" || r.URL.Query().Get("provider") != "aws" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				testutil.RespondJSON(w, tt.status, tt.body)
+			})
+
+			regions, err := apiClient.ListRegions("aws")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ListRegions() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListRegions() error = %v", err)
+			}
+			if strings.Join(regions, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("ListRegions() = %v, want %v", regions, tt.want)
+			}
+		})
+	}
+}
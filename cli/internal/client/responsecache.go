@@ -0,0 +1,79 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/cache"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+// How long cached responses stay fresh. Only data that rarely changes is cached; user info is
+// not, because commands use it to check that the session is still valid.
+const (
+	blueprintCacheTTL = 10 * time.Minute
+	regionCacheTTL    = time.Hour
+	hostOptionsTTL    = time.Hour
+)
+
+// newResponseCache returns the cache for GET responses, or nil when --no-cache is set. It persists
+// to the config directory's cache folder, or stays in memory when that cannot be located.
+func newResponseCache(cfg *config.Config) *cache.Cache {
+	if cfg.NoCache {
+		return nil
+	}
+
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		logger.Debug("Caching responses in memory only: %v", err)
+		return cache.New()
+	}
+	return cache.Open(dir)
+}
+
+// cacheKey scopes path to the configured API and the current credentials, so accounts and servers
+// never see each other's cached responses.
+func (c *Client) cacheKey(path string) string {
+	sum := sha256.Sum256([]byte(c.config.Token()))
+	return strings.TrimRight(c.config.APIURL, "/") + "|" + hex.EncodeToString(sum[:8]) + "|" + path
+}
+
+// cachedGet decodes the response to GET path into result, serving it from the cache while it is
+// fresh and caching it for ttl otherwise.
+func (c *Client) cachedGet(path string, ttl time.Duration, result interface{}) error {
+	if c.cache == nil {
+		return c.makeRequest("GET", path, nil, result)
+	}
+
+	key := c.cacheKey(path)
+	if data, ok := c.cache.Get(key); ok {
+		if err := json.Unmarshal(data, result); err == nil {
+			logger.Debug("Using cached response for %s", path)
+			return nil
+		}
+		c.cache.Invalidate(key)
+	}
+
+	var raw json.RawMessage
+	if err := c.makeRequest("GET", path, nil, &raw); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(raw, result); err != nil {
+		return err
+	}
+
+	c.cache.Set(key, raw, ttl)
+	return nil
+}
+
+// invalidateCached drops the cached response for path after a change makes it stale.
+func (c *Client) invalidateCached(path string) {
+	if c.cache != nil {
+		c.cache.Invalidate(c.cacheKey(path))
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/testutil"
+)
+
+// newCachingClient returns a client with response caching on and counts the requests its fake API
+// receives.
+func newCachingClient(t *testing.T, requests *atomic.Int32) (*Client, *config.Config) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		testutil.RespondJSON(w, http.StatusOK, `{"message":"response `+strconv.Itoa(int(n))+`"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := testutil.NewConfig(t, server.URL)
+	cfg.NoCache = false
+	return New(cfg), cfg
+}
+
+func TestCachedGet(t *testing.T) {
+	var requests atomic.Int32
+	apiClient, cfg := newCachingClient(t, &requests)
+
+	get := func(ttl time.Duration) string {
+		t.Helper()
+		var result Message
+		if err := apiClient.cachedGet("/api/v1/blueprints/ranges/1", ttl, &result); err != nil {
+			t.Fatalf("cachedGet() error = %v", err)
+		}
+		return result.Message
+	}
+
+	if got := get(time.Minute); got != "response 1" {
+		t.Fatalf("first get = %q, want response 1", got)
+	}
+	if got := get(time.Minute); got != "response 1" || requests.Load() != 1 {
+		t.Errorf("second get = %q after %d requests, want the cached response", got, requests.Load())
+	}
+
+	// Another client, as in the next command, reads the response from disk
+	if got, err := New(cfg).GetBlueprintRangeRaw(1); err != nil || requests.Load() != 1 {
+		t.Errorf("new client got %s, %v after %d requests, want the cached response", got, err, requests.Load())
+	}
+
+	apiClient.invalidateCached("/api/v1/blueprints/ranges/1")
+	if got := get(time.Minute); got != "response 2" {
+		t.Errorf("get after invalidation = %q, want response 2", got)
+	}
+}
+
+func TestCachedGetExpiry(t *testing.T) {
+	var requests atomic.Int32
+	apiClient, _ := newCachingClient(t, &requests)
+
+	var result Message
+	if err := apiClient.cachedGet("/api/v1/ranges/regions", 10*time.Millisecond, &result); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if err := apiClient.cachedGet("/api/v1/ranges/regions", 10*time.Millisecond, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := requests.Load(); n != 2 || result.Message != "response 2" {
+		t.Errorf("got %q after %d requests, want the expired response fetched again", result.Message, n)
+	}
+}
+
+func TestCacheKeyScope(t *testing.T) {
+	base := &Client{config: &config.Config{APIURL: "https://api.example.com/", AuthToken: "token-a"}}
+	key := base.cacheKey("/api/v1/blueprints/ranges/1")
+
+	tests := []struct {
+		name   string
+		config *config.Config
+		path   string
+		same   bool
+	}{
+		{name: "same API without trailing slash", config: &config.Config{APIURL: "https://api.example.com", AuthToken: "token-a"}, path: "/api/v1/blueprints/ranges/1", same: true},
+		{name: "other path", config: &config.Config{APIURL: "https://api.example.com", AuthToken: "token-a"}, path: "/api/v1/blueprints/ranges/2"},
+		{name: "other account", config: &config.Config{APIURL: "https://api.example.com", AuthToken: "token-b"}, path: "/api/v1/blueprints/ranges/1"},
+		{name: "API key takes over", config: &config.Config{APIURL: "https://api.example.com", AuthToken: "token-a", APIKey: "key"}, path: "/api/v1/blueprints/ranges/1"},
+		{name: "other server", config: &config.Config{APIURL: "https://staging.example.com", AuthToken: "token-a"}, path: "/api/v1/blueprints/ranges/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&Client{config: tt.config}).cacheKey(tt.path)
+			if (got == key) != tt.same {
+				t.Errorf("cacheKey() = %q, base %q, want same = %v", got, key, tt.same)
+			}
+		})
+	}
+
+	if strings.Contains(key, "token-a") {
+		t.Errorf("cacheKey() = %q contains the token", key)
+	}
+}
+
+func TestNoCache(t *testing.T) {
+	var requests atomic.Int32
+	apiClient, _ := newCachingClient(t, &requests)
+	apiClient.config.NoCache = true
+	apiClient.cache = newResponseCache(apiClient.config)
+
+	var result Message
+	for i := 0; i < 2; i++ {
+		if err := apiClient.cachedGet("/api/v1/blueprints/ranges/1", time.Minute, &result); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("sent %d requests with --no-cache, want 2", n)
+	}
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// sharedTransport is reused by every Client so connections to the API stay open across clients
+// and across the many requests made by bulk commands, rather than being re-dialed each time.
+var sharedTransport = newTransport()
+
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.MaxIdleConns = 100
+	// The CLI talks to a single API host, so allow roughly as many idle connections to it as
+	// bulk commands may have requests in flight
+	transport.MaxIdleConnsPerHost = 16
+	transport.IdleConnTimeout = 90 * time.Second
+
+	return transport
+}
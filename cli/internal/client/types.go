@@ -17,6 +17,11 @@ type LoginResponse struct {
 	Success bool `json:"success"`
 }
 
+type RefreshResponse struct {
+	Success   bool       `json:"success"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
 type UserInfo struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
@@ -40,6 +45,11 @@ type AzureSecrets struct {
 	SubscriptionID string `json:"azure_subscription_id"`
 }
 
+type GCPSecrets struct {
+	ProjectID          string `json:"gcp_project_id"`
+	ServiceAccountJSON string `json:"gcp_service_account_json"`
+}
+
 type CloudSecretStatus struct {
 	HasCredentials bool       `json:"has_credentials"`
 	CreatedAt      *time.Time `json:"created_at,omitempty"`
@@ -48,6 +58,7 @@ type CloudSecretStatus struct {
 type UserSecretResponse struct {
 	AWS   CloudSecretStatus `json:"aws"`
 	Azure CloudSecretStatus `json:"azure"`
+	GCP   CloudSecretStatus `json:"gcp"`
 }
 
 type BlueprintRangeHeader struct {
@@ -59,11 +70,36 @@ type BlueprintRangeHeader struct {
 	VPN         bool   `json:"vpn"`
 }
 
+// CatalogBlueprint is a blueprint shared in the server's public catalog.
+type CatalogBlueprint struct {
+	ID          int      `json:"id"`
+	Provider    string   `json:"provider"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	VNC         bool     `json:"vnc"`
+	VPN         bool     `json:"vpn"`
+}
+
+// CatalogFilter narrows a catalog listing. Servers that do not filter are covered by the caller
+// filtering the result again.
+type CatalogFilter struct {
+	Provider string
+	Tags     []string
+}
+
 type BlueprintRange struct {
 	BlueprintRangeHeader
 	VPCs []BlueprintVPC `json:"vpcs"`
 }
 
+// BlueprintVersion describes one saved version of a blueprint.
+type BlueprintVersion struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+}
+
 type BlueprintVPCHeader struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
@@ -109,6 +145,9 @@ type DeployedRangeHeader struct {
 	Region      string    `json:"region"`
 	VNC         bool      `json:"vnc"`
 	VPN         bool      `json:"vpn"`
+
+	// Labels are user-defined key/value pairs; servers without label support omit them
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 type DeployedRange struct {
@@ -146,6 +185,28 @@ type DeployedHost struct {
 	Tags       []string `json:"tags,omitempty"`
 	ResourceID string   `json:"resource_id"`
 	IPAddress  string   `json:"ip_address"`
+	Status     string   `json:"status,omitempty"`
+}
+
+// Quota describes account limits. A nil maximum means the limit is not enforced.
+type Quota struct {
+	MaxRanges  *int `json:"max_ranges,omitempty"`
+	UsedRanges int  `json:"used_ranges"`
+	MaxHosts   *int `json:"max_hosts,omitempty"`
+	UsedHosts  int  `json:"used_hosts"`
+}
+
+type CreateTokenRequest struct {
+	Scopes     []string `json:"scopes"`
+	TTLSeconds int      `json:"ttl_seconds"`
+}
+
+// ScopedToken is a short-lived token limited to the listed scopes. Token is only returned on creation.
+type ScopedToken struct {
+	ID        string    `json:"id"`
+	Token     string    `json:"token,omitempty"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 type DeployRangeRequest struct {
@@ -168,15 +229,55 @@ type Job struct {
 	ErrorMessage string      `json:"error_message,omitempty"`
 }
 
+type JobLogs struct {
+	Lines      []string `json:"lines"`
+	NextOffset int      `json:"next_offset"`
+}
+
 type JobSubmissionResponse struct {
 	ARQJobID string `json:"arq_job_id"`
 	Detail   string `json:"detail"`
+
+	// EstimatedSeconds is how long the server expects the job to take; servers without estimates
+	// omit it
+	EstimatedSeconds int `json:"estimated_seconds,omitempty"`
+}
+
+// HostPowerResponse is returned by host power operations. Servers that run them asynchronously return
+// a job ID; the others report the host's resulting state directly.
+type HostPowerResponse struct {
+	ARQJobID string `json:"arq_job_id,omitempty"`
+	State    string `json:"state,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+type ScaleHostRequest struct {
+	Size int `json:"size"`
+}
+
+// HostOptions lists the host settings a server accepts for a provider.
+type HostOptions struct {
+	Sizes []int `json:"sizes"`
 }
 
 type RangeKeyResponse struct {
 	RangePrivateKey string `json:"range_private_key"`
 }
 
+// VNCInfo holds the address of a range's VNC console and the credentials to log in to it.
+type VNCInfo struct {
+	URL      string `json:"url"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// VPNConfig is a client configuration for a range's VPN. Type is "openvpn" or "wireguard" when the
+// server reports it.
+type VPNConfig struct {
+	Config string `json:"config"`
+	Type   string `json:"type,omitempty"`
+}
+
 type Message struct {
 	Message string `json:"message"`
 }
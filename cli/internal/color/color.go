@@ -0,0 +1,82 @@
+// Package color decides whether the CLI colors its output and wraps text in ANSI colors when it
+// does.
+//
+// The --color flag takes precedence: "always" and "never" apply regardless of the environment. With
+// "auto", the default, a non-empty NO_COLOR turns color off, then a CLICOLOR_FORCE other than "0"
+// turns it on, and otherwise color is used only when stdout is a terminal that is not TERM=dumb.
+package color
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+const (
+	ModeAuto   = "auto"
+	ModeAlways = "always"
+	ModeNever  = "never"
+)
+
+const (
+	codeRed    = "31"
+	codeGreen  = "32"
+	codeYellow = "33"
+	codeBold   = "1"
+)
+
+var enabled bool
+
+// SetMode applies a --color value; an empty mode means auto.
+func SetMode(mode string) error {
+	switch mode {
+	case ModeAlways:
+		enabled = true
+	case ModeNever:
+		enabled = false
+	case ModeAuto, "":
+		enabled = detect()
+	default:
+		return fmt.Errorf("invalid color mode: %s (valid: auto, always, never)", mode)
+	}
+	return nil
+}
+
+// Enabled reports whether output should be colored.
+func Enabled() bool {
+	return enabled
+}
+
+func detect() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	return os.Getenv("TERM") != "dumb" && utils.IsTerminalOutput()
+}
+
+func wrap(code, s string) string {
+	if !enabled {
+		return s
+	}
+	return "\033[" + code + "m" + s + "\033[0m"
+}
+
+func Red(s string) string {
+	return wrap(codeRed, s)
+}
+
+func Green(s string) string {
+	return wrap(codeGreen, s)
+}
+
+func Yellow(s string) string {
+	return wrap(codeYellow, s)
+}
+
+func Bold(s string) string {
+	return wrap(codeBold, s)
+}
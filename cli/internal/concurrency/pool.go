@@ -0,0 +1,44 @@
+package concurrency
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultLimit is the default number of concurrent API calls made by bulk commands.
+const DefaultLimit = 4
+
+// Run calls fn for every index in [0, n) using at most limit goroutines. It waits for all calls to
+// finish and returns their errors joined in index order, or nil if every call succeeded.
+func Run(n, limit int, fn func(i int) error) error {
+	if limit < 1 {
+		limit = 1
+	}
+
+	errs := make([]error, n)
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// ValidateLimit checks a user-supplied concurrency limit.
+func ValidateLimit(limit int) error {
+	if limit < 1 {
+		return fmt.Errorf("concurrency must be at least 1")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package concurrency
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunRespectsLimit(t *testing.T) {
+	for _, limit := range []int{1, 2, 4, 16} {
+		t.Run(fmt.Sprint(limit), func(t *testing.T) {
+			var running, peak atomic.Int32
+			var calls sync.Map
+
+			err := Run(20, limit, func(i int) error {
+				current := running.Add(1)
+				defer running.Add(-1)
+
+				for {
+					seen := peak.Load()
+					if current <= seen || peak.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+
+				calls.Store(i, true)
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if got := int(peak.Load()); got > limit {
+				t.Errorf("peak concurrency = %d, want at most %d", got, limit)
+			}
+			for i := 0; i < 20; i++ {
+				if _, ok := calls.Load(i); !ok {
+					t.Errorf("fn not called for index %d", i)
+				}
+			}
+		})
+	}
+}
+
+func TestRunLimitBelowOne(t *testing.T) {
+	var running, peak atomic.Int32
+	err := Run(5, 0, func(i int) error {
+		if current := running.Add(1); current > peak.Load() {
+			peak.Store(current)
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := peak.Load(); got != 1 {
+		t.Errorf("peak concurrency = %d, want 1", got)
+	}
+}
+
+func TestRunAggregatesErrors(t *testing.T) {
+	errOdd := errors.New("odd index")
+
+	err := Run(6, 3, func(i int) error {
+		// Later indexes finish first, so the order below comes from the index, not completion
+		time.Sleep(time.Duration(6-i) * time.Millisecond)
+		if i%2 == 1 {
+			return fmt.Errorf("item %d: %w", i, errOdd)
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errOdd) {
+		t.Fatalf("Run() error = %v, want it to wrap %v", err, errOdd)
+	}
+	if want := "item 1: odd index\nitem 3: odd index\nitem 5: odd index"; err.Error() != want {
+		t.Errorf("Run() error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestRunNoWork(t *testing.T) {
+	if err := Run(0, 4, func(i int) error {
+		t.Errorf("fn called with %d", i)
+		return nil
+	}); err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+}
+
+func TestValidateLimit(t *testing.T) {
+	for _, limit := range []int{-1, 0} {
+		if err := ValidateLimit(limit); err == nil || !strings.Contains(err.Error(), "at least 1") {
+			t.Errorf("ValidateLimit(%d) error = %v, want at least 1", limit, err)
+		}
+	}
+	for _, limit := range []int{1, DefaultLimit, 64} {
+		if err := ValidateLimit(limit); err != nil {
+			t.Errorf("ValidateLimit(%d) error = %v", limit, err)
+		}
+	}
+}
@@ -2,30 +2,122 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 )
 
+// ErrReadOnly is returned by Save when the config directory could not be written at load time and
+// the CLI is running on an in-memory config.
+var ErrReadOnly = errors.New("config directory is not writable, so settings cannot be saved")
+
+// readOnlyWarning makes sure the fallback warning is printed once even when several commands load
+// the config in the same run.
+var readOnlyWarning sync.Once
+
+// APIKeyEnv names the environment variable that supplies a token, such as a scoped token from
+// 'auth token create', in place of the saved session.
+const APIKeyEnv = "OPENLABS_API_KEY"
+
+// HomeEnv names the environment variable that moves the directory holding the config file, keys,
+// cache, and state away from ~/.openlabs.
+const HomeEnv = "OPENLABS_HOME"
+
+// configDirOverride is set by --config-dir and takes precedence over HomeEnv.
+var configDirOverride string
+
+// Defaults for the deploy time estimate, used when the server gives none.
+const (
+	DefaultDeployEstimateBase    = 5 * time.Minute
+	DefaultDeployEstimatePerHost = time.Minute
+)
+
+func warnReadOnly(format string, args ...interface{}) {
+	readOnlyWarning.Do(func() {
+		fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+	})
+}
+
 type Config struct {
+	SchemaVersion int           `json:"schema_version"`
 	APIURL        string        `json:"api_url"`
 	AuthToken     string        `json:"auth_token"`
 	EncryptionKey string        `json:"encryption_key"`
+	CSRFToken     string        `json:"csrf_token,omitempty"`
+	CSRFCookie    string        `json:"csrf_cookie,omitempty"`
 	OutputFormat  string        `json:"output_format"`
 	Timeout       time.Duration `json:"timeout"`
 	SSHKeyPath    string        `json:"ssh_key_path"`
+	TimeFormat    string        `json:"time_format,omitempty"`
 	Debug         bool          `json:"debug"`
+
+	// FrontendURL is the web UI address; when empty WebURL derives it from APIURL
+	FrontendURL string `json:"web_url,omitempty"`
+
+	// TelemetryEnabled holds the answer to the usage data opt-in; nil means the user has not been
+	// asked yet
+	TelemetryEnabled *bool `json:"telemetry_enabled,omitempty"`
+
+	// TelemetryURL is where usage events are sent; when empty TelemetryEndpoint derives it from APIURL
+	TelemetryURL string `json:"telemetry_url,omitempty"`
+
+	// DeployEstimateBase and DeployEstimatePerHost tune the deploy time estimate shown when the
+	// server does not give one; zero means the built-in default
+	DeployEstimateBase    time.Duration `json:"deploy_estimate_base,omitempty"`
+	DeployEstimatePerHost time.Duration `json:"deploy_estimate_per_host,omitempty"`
+
+	// AuditLog turns on the local record of deploys, destroys, and other changes kept in audit.log
+	AuditLog bool `json:"audit_log,omitempty"`
+
+	// FormatOverrides maps command paths such as "range.jobs" to the output format they use
+	// when --format is not given
+	FormatOverrides map[string]string `json:"format_overrides,omitempty"`
+
+	// Format is the output format for this invocation: --format, or the running command's format
+	// override or OutputFormat. It is kept apart from OutputFormat so that saving the config never
+	// turns one run's format into the default
+	Format string `json:"-"`
+
+	// NoDiscovery skips the API discovery lookup for this invocation; it is set by --no-discovery
+	NoDiscovery bool `json:"-"`
+
+	// RequestTimeout replaces Timeout for this invocation's HTTP requests; it is set from
+	// --request-timeout or the running command's default
+	RequestTimeout time.Duration `json:"-"`
+
+	// NoCache bypasses the response cache for this invocation; it is set by --no-cache
+	NoCache bool `json:"-"`
+
+	// Strict404 reports every 404 from list endpoints as an error instead of an empty list; it is
+	// set by --strict-404
+	Strict404 bool `json:"-"`
+
+	// APIKey comes from OPENLABS_API_KEY and takes precedence over AuthToken; it is never saved
+	APIKey string `json:"-"`
+
+	// readOnly is set when the config could not be written to disk, so changes are not persisted
+	readOnly bool
+
+	// csrfChanged is set when a request captured a new CSRF token that has not been saved yet
+	csrfChanged bool
 }
 
 func DefaultConfig() *Config {
-	homeDir, _ := os.UserHomeDir()
+	configDir, _ := getConfigDir()
 	return &Config{
-		APIURL:       "https://api.openlabs.sh",
-		OutputFormat: "table",
-		Timeout:      5 * time.Minute,
-		SSHKeyPath:   filepath.Join(homeDir, ".openlabs", "keys"),
-		Debug:        false,
+		SchemaVersion: CurrentSchemaVersion,
+		APIURL:        "https://api.openlabs.sh",
+		OutputFormat:  "table",
+		Timeout:       5 * time.Minute,
+		SSHKeyPath:    filepath.Join(configDir, "keys"),
+		Debug:         false,
 	}
 }
 
@@ -38,22 +130,17 @@ func Load() (*Config, error) {
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		config := DefaultConfig()
 		if err := config.Save(); err != nil {
-			return nil, err
+			if !isPermissionError(err) {
+				return nil, err
+			}
+			warnReadOnly("cannot create %s (%v); using default settings, changes will not be saved", configPath, err)
+			config.readOnly = true
 		}
+		config.APIKey = os.Getenv(APIKeyEnv)
 		return config, nil
 	}
 
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
-
-	var config Config
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
-	}
-
-	return &config, nil
+	return loadFile(configPath)
 }
 
 func LoadFromPath(configPath string) (*Config, error) {
@@ -61,20 +148,57 @@ func LoadFromPath(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("config file does not exist: %s", configPath)
 	}
 
+	return loadFile(configPath)
+}
+
+// loadFile reads a config file, upgrading it in place first if it uses an older schema version.
+func loadFile(configPath string) (*Config, error) {
+	readOnly := false
+
+	result, err := Migrate(configPath)
+	switch {
+	case err != nil && isPermissionError(err):
+		// Upgrade in memory below instead, so an old config in a read-only location stays usable
+		warnReadOnly("cannot upgrade config file %s in place (%v); changes will not be saved", configPath, err)
+		readOnly = true
+	case err != nil:
+		return nil, err
+	case result.Migrated():
+		fmt.Fprintf(os.Stderr, "Upgraded config file %s to schema version %d (backup: %s)\n", configPath, result.ToVersion, result.BackupPath)
+	}
+
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	if readOnly {
+		if data, _, err = migrateData(data); err != nil {
+			return nil, err
+		}
+	}
+
 	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	config.readOnly = readOnly
+	config.APIKey = os.Getenv(APIKeyEnv)
 
 	return &config, nil
 }
 
+// isPermissionError reports whether err comes from a location the CLI may not write to, such as a
+// read-only home directory or filesystem.
+func isPermissionError(err error) bool {
+	return errors.Is(err, fs.ErrPermission) || errors.Is(err, syscall.EROFS)
+}
+
 func (c *Config) Save() error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
 	configPath, err := getConfigPath()
 	if err != nil {
 		return err
@@ -84,12 +208,17 @@ func (c *Config) Save() error {
 		return err
 	}
 
+	c.SchemaVersion = CurrentSchemaVersion
 	data, err := json.MarshalIndent(c, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	return os.WriteFile(configPath, data, 0600)
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return err
+	}
+	c.csrfChanged = false
+	return nil
 }
 
 func (c *Config) SetAPIURL(url string) error {
@@ -97,6 +226,40 @@ func (c *Config) SetAPIURL(url string) error {
 	return c.Save()
 }
 
+func (c *Config) SetWebURL(webURL string) error {
+	c.FrontendURL = strings.TrimRight(webURL, "/")
+	return c.Save()
+}
+
+// WebURL returns the base URL of the web UI: the configured web_url, or the API URL with a leading
+// "api." removed from its host. It returns "" when neither applies.
+func (c *Config) WebURL() string {
+	if c.FrontendURL != "" {
+		return c.FrontendURL
+	}
+
+	parsed, err := url.Parse(c.APIURL)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+
+	host, ok := strings.CutPrefix(parsed.Host, "api.")
+	if !ok {
+		return ""
+	}
+
+	return (&url.URL{Scheme: parsed.Scheme, Host: host}).String()
+}
+
+// JobURL returns the web UI page for a job, or "" when the web UI address is unknown.
+func (c *Config) JobURL(jobID string) string {
+	webURL := c.WebURL()
+	if webURL == "" {
+		return ""
+	}
+	return webURL + "/jobs/" + url.PathEscape(jobID)
+}
+
 func (c *Config) SetOutputFormat(format string) error {
 	validFormats := map[string]bool{
 		"table": true,
@@ -112,19 +275,191 @@ func (c *Config) SetOutputFormat(format string) error {
 	return c.Save()
 }
 
+// SetFormatOverride sets the output format for commands under commandPath, such as "range.jobs". An
+// empty format removes the override.
+func (c *Config) SetFormatOverride(commandPath, format string) error {
+	if format == "" {
+		delete(c.FormatOverrides, commandPath)
+		return c.Save()
+	}
+
+	if c.FormatOverrides == nil {
+		c.FormatOverrides = make(map[string]string)
+	}
+	c.FormatOverrides[commandPath] = format
+	return c.Save()
+}
+
+// FormatFor returns the output format for the command with the given path, such as
+// ["range", "jobs", "show"]: the override for the longest matching prefix of the path, or the
+// global output format.
+func (c *Config) FormatFor(commandPath []string) string {
+	for i := len(commandPath); i > 0; i-- {
+		if format, ok := c.FormatOverrides[strings.Join(commandPath[:i], ".")]; ok {
+			return format
+		}
+	}
+	return c.OutputFormat
+}
+
+func (c *Config) SetTimeFormat(format string) error {
+	c.TimeFormat = format
+	return c.Save()
+}
+
+// SetTelemetry records whether anonymous usage data may be sent. Only that answer is saved, since
+// the question is asked after the run's flags have been applied to c.
+func (c *Config) SetTelemetry(enabled bool) error {
+	c.TelemetryEnabled = &enabled
+	return c.saveFields(func(saved *Config) {
+		saved.TelemetryEnabled = &enabled
+	})
+}
+
+func (c *Config) SetTelemetryURL(telemetryURL string) error {
+	c.TelemetryURL = strings.TrimRight(telemetryURL, "/")
+	return c.Save()
+}
+
+// SetAuditLog turns the local audit log on or off.
+func (c *Config) SetAuditLog(enabled bool) error {
+	c.AuditLog = enabled
+	return c.Save()
+}
+
+// SetDeployEstimate sets the fixed and per-host parts of the deploy time estimate. Zero restores the
+// default for that part.
+func (c *Config) SetDeployEstimate(base, perHost time.Duration) error {
+	c.DeployEstimateBase = base
+	c.DeployEstimatePerHost = perHost
+	return c.Save()
+}
+
+// DeployEstimate returns a rough time for a range with the given number of hosts to deploy: a fixed
+// part for the network and jumpbox plus a part per host.
+func (c *Config) DeployEstimate(hosts int) time.Duration {
+	base, perHost := c.DeployEstimateBase, c.DeployEstimatePerHost
+	if base == 0 {
+		base = DefaultDeployEstimateBase
+	}
+	if perHost == 0 {
+		perHost = DefaultDeployEstimatePerHost
+	}
+	return base + time.Duration(hosts)*perHost
+}
+
+// TelemetryOn reports whether the user has opted in to sending usage data.
+func (c *Config) TelemetryOn() bool {
+	return c.TelemetryEnabled != nil && *c.TelemetryEnabled
+}
+
+// TelemetryEndpoint returns where usage events are sent: the configured telemetry_url, or the
+// telemetry endpoint of the configured API.
+func (c *Config) TelemetryEndpoint() string {
+	if c.TelemetryURL != "" {
+		return c.TelemetryURL
+	}
+	return strings.TrimRight(c.APIURL, "/") + "/api/v1/telemetry"
+}
+
+// ReadOnly reports whether the config is held in memory only because its file cannot be written.
+func (c *Config) ReadOnly() bool {
+	return c.readOnly
+}
+
+// Token returns the token requests authenticate with: the OPENLABS_API_KEY value when set, otherwise
+// the saved session token.
+func (c *Config) Token() string {
+	if c.APIKey != "" {
+		return c.APIKey
+	}
+	return c.AuthToken
+}
+
+// HTTPTimeout returns the timeout for HTTP requests: RequestTimeout when set, otherwise Timeout.
+func (c *Config) HTTPTimeout() time.Duration {
+	if c.RequestTimeout > 0 {
+		return c.RequestTimeout
+	}
+	return c.Timeout
+}
+
 func (c *Config) SetCredentials(authToken, encryptionKey string) error {
 	c.AuthToken = authToken
 	c.EncryptionKey = encryptionKey
 	return c.Save()
 }
 
+// SetCSRFToken records a CSRF token issued by the server and the cookie it came in. The token is kept
+// in memory for the rest of the run; SaveCSRFToken persists it.
+func (c *Config) SetCSRFToken(token, cookieName string) {
+	c.CSRFToken = token
+	c.CSRFCookie = cookieName
+	c.csrfChanged = true
+}
+
+// SaveCSRFToken saves a CSRF token captured during this run. It is only saved alongside a saved
+// session, and does nothing when the token has not changed. Only the token is written, so settings
+// this run changed in memory, such as --api-url, stay out of the config file.
+func (c *Config) SaveCSRFToken() error {
+	if !c.csrfChanged || c.AuthToken == "" {
+		return nil
+	}
+
+	token, cookieName := c.CSRFToken, c.CSRFCookie
+	err := c.saveFields(func(saved *Config) {
+		saved.CSRFToken = token
+		saved.CSRFCookie = cookieName
+	})
+	if err != nil {
+		return err
+	}
+	c.csrfChanged = false
+	return nil
+}
+
+// saveFields applies update to the config as saved on disk and saves that, for changes made after
+// the run's flags were applied to c, which must not be saved with them.
+func (c *Config) saveFields(update func(saved *Config)) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	saved, err := Load()
+	if err != nil {
+		return err
+	}
+	update(saved)
+	return saved.Save()
+}
+
 func (c *Config) ClearCredentials() error {
 	c.AuthToken = ""
 	c.EncryptionKey = ""
+	c.CSRFToken = ""
+	c.CSRFCookie = ""
 	return c.Save()
 }
 
+// SetConfigDir moves the openlabs directory for this run, overriding OPENLABS_HOME. It must be called
+// before the config is loaded; an empty dir restores the default.
+func SetConfigDir(dir string) {
+	configDirOverride = dir
+}
+
 func getConfigDir() (string, error) {
+	dir := configDirOverride
+	if dir == "" {
+		dir = os.Getenv(HomeEnv)
+	}
+	if dir != "" {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve config directory %s: %w", dir, err)
+		}
+		return absDir, nil
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get home directory: %w", err)
@@ -156,3 +491,17 @@ func ensureConfigDir() error {
 func GetConfigPath() (string, error) {
 	return getConfigPath()
 }
+
+// GetConfigDir returns the openlabs directory: --config-dir, then OPENLABS_HOME, then ~/.openlabs.
+func GetConfigDir() (string, error) {
+	return getConfigDir()
+}
+
+// GetCacheDir returns the directory cached API responses are kept in.
+func GetCacheDir() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "cache"), nil
+}
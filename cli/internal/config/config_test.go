@@ -0,0 +1,275 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// makeReadOnly makes dir unwritable for the rest of the test. It skips the test when permissions are
+// not enforced, as for root.
+func makeReadOnly(t *testing.T, dir string) {
+	t.Helper()
+
+	if err := os.Chmod(dir, 0500); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(dir, 0700) })
+
+	probe := filepath.Join(dir, "probe")
+	if err := os.WriteFile(probe, nil, 0600); err == nil {
+		_ = os.Remove(probe)
+		t.Skip("directory permissions are not enforced for this user")
+	}
+}
+
+func TestLoadUnwritableConfigDir(t *testing.T) {
+	parent := t.TempDir()
+	makeReadOnly(t, parent)
+	home := filepath.Join(parent, ".openlabs")
+	t.Setenv(HomeEnv, home)
+	t.Setenv(APIKeyEnv, "key-from-env")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v, want the in-memory defaults", err)
+	}
+	if !cfg.ReadOnly() {
+		t.Error("ReadOnly() = false, want true")
+	}
+	if cfg.APIURL != DefaultConfig().APIURL || cfg.OutputFormat != "table" {
+		t.Errorf("Load() = %+v, want the default settings", cfg)
+	}
+	if cfg.Token() != "key-from-env" {
+		t.Errorf("Token() = %q, want the %s value", cfg.Token(), APIKeyEnv)
+	}
+
+	if err := cfg.SetOutputFormat("json"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("SetOutputFormat() error = %v, want ErrReadOnly", err)
+	}
+	if cfg.OutputFormat != "json" {
+		t.Errorf("output format = %q, want the change kept in memory", cfg.OutputFormat)
+	}
+	if _, err := os.Stat(home); !os.IsNotExist(err) {
+		t.Errorf("config directory created despite the read-only parent (stat error: %v)", err)
+	}
+}
+
+func TestLoadReadOnlyConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(HomeEnv, dir)
+
+	original := `{"api_url":"https://api.example.com","auth_token":"tok","timeout":"2m"}`
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(original), 0600); err != nil {
+		t.Fatal(err)
+	}
+	makeReadOnly(t, dir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.ReadOnly() {
+		t.Error("ReadOnly() = false, want true")
+	}
+	if cfg.Timeout != 2*time.Minute || cfg.AuthToken != "tok" {
+		t.Errorf("Load() = %+v, want the old config upgraded in memory", cfg)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != original {
+		t.Errorf("config file changed to %s, want it left as is", data)
+	}
+}
+
+func TestLoadOtherErrorsAreReported(t *testing.T) {
+	// A config directory below a regular file fails for a reason other than permissions
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(HomeEnv, filepath.Join(file, ".openlabs"))
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load() error = nil, want the failure reported")
+	}
+}
+
+func TestHTTPTimeout(t *testing.T) {
+	cfg := &Config{Timeout: time.Minute}
+	if got := cfg.HTTPTimeout(); got != time.Minute {
+		t.Errorf("HTTPTimeout() = %v, want the configured timeout", got)
+	}
+
+	cfg.RequestTimeout = 10 * time.Minute
+	if got := cfg.HTTPTimeout(); got != 10*time.Minute {
+		t.Errorf("HTTPTimeout() = %v, want the command's request timeout", got)
+	}
+}
+
+// setConfigDir sets --config-dir for the rest of the test.
+func setConfigDir(t *testing.T, dir string) {
+	t.Helper()
+
+	SetConfigDir(dir)
+	t.Cleanup(func() { SetConfigDir("") })
+}
+
+func TestGetConfigDirPrecedence(t *testing.T) {
+	home := t.TempDir()
+	envDir := t.TempDir()
+	flagDir := t.TempDir()
+	workDir := t.TempDir()
+
+	tests := []struct {
+		name string
+		env  string
+		flag string
+		want string
+	}{
+		{name: "default", want: filepath.Join(home, ".openlabs")},
+		{name: "OPENLABS_HOME", env: envDir, want: envDir},
+		{name: "--config-dir", flag: flagDir, want: flagDir},
+		{name: "--config-dir over OPENLABS_HOME", env: envDir, flag: flagDir, want: flagDir},
+		{name: "relative OPENLABS_HOME", env: "lab", want: filepath.Join(workDir, "lab")},
+		{name: "relative --config-dir", env: envDir, flag: "lab", want: filepath.Join(workDir, "lab")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", home)
+			t.Setenv(HomeEnv, tt.env)
+			t.Chdir(workDir)
+			setConfigDir(t, tt.flag)
+
+			got, err := GetConfigDir()
+			if err != nil {
+				t.Fatalf("GetConfigDir() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetConfigDir() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathsFollowConfigDir(t *testing.T) {
+	envDir := t.TempDir()
+	flagDir := t.TempDir()
+	t.Setenv(HomeEnv, envDir)
+	setConfigDir(t, flagDir)
+
+	configPath, err := GetConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	statePath, err := getStatePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, got := range map[string]string{
+		"config file":  configPath,
+		"cache":        cacheDir,
+		"state file":   statePath,
+		"default keys": DefaultConfig().SSHKeyPath,
+	} {
+		if filepath.Dir(got) != flagDir {
+			t.Errorf("%s at %s, want it in %s", name, got, flagDir)
+		}
+	}
+
+	// Everything written lands in the override, never in OPENLABS_HOME
+	if _, err := Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := (&State{LastRangeID: 7}).Save(); err != nil {
+		t.Fatalf("State.Save() error = %v", err)
+	}
+	for _, name := range []string{"config.json", "state.json"} {
+		if _, err := os.Stat(filepath.Join(flagDir, name)); err != nil {
+			t.Errorf("%s not written to --config-dir: %v", name, err)
+		}
+	}
+	if entries, _ := os.ReadDir(envDir); len(entries) != 0 {
+		t.Errorf("OPENLABS_HOME was written to despite --config-dir: %v", entries)
+	}
+}
+
+func TestSaveCSRFTokenOnlySavesToken(t *testing.T) {
+	t.Setenv(HomeEnv, t.TempDir())
+
+	cfg := DefaultConfig()
+	cfg.AuthToken = "session"
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Values the run's flags set in memory
+	cfg.APIURL = "https://staging.example.com"
+	cfg.OutputFormat = "json"
+	cfg.TimeFormat = "utc"
+
+	cfg.SetCSRFToken("csrf-1", "csrf_token")
+	if err := cfg.SaveCSRFToken(); err != nil {
+		t.Fatalf("SaveCSRFToken() error = %v", err)
+	}
+
+	saved, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.CSRFToken != "csrf-1" || saved.CSRFCookie != "csrf_token" {
+		t.Errorf("saved token = %q in %q, want %q in %q", saved.CSRFToken, saved.CSRFCookie, "csrf-1", "csrf_token")
+	}
+	defaults := DefaultConfig()
+	if saved.APIURL != defaults.APIURL || saved.OutputFormat != defaults.OutputFormat || saved.TimeFormat != "" {
+		t.Errorf("saved api_url %q, output_format %q, time_format %q, want the settings on disk left alone", saved.APIURL, saved.OutputFormat, saved.TimeFormat)
+	}
+	if saved.AuthToken != "session" {
+		t.Errorf("saved auth token = %q, want the session kept", saved.AuthToken)
+	}
+}
+
+func TestSetTelemetryOnlySavesAnswer(t *testing.T) {
+	t.Setenv(HomeEnv, t.TempDir())
+
+	cfg := DefaultConfig()
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Values the run's flags set in memory before the opt-in question
+	cfg.APIURL = "https://staging.example.com"
+	cfg.OutputFormat = "yaml"
+	cfg.TimeFormat = "utc"
+
+	if err := cfg.SetTelemetry(true); err != nil {
+		t.Fatalf("SetTelemetry() error = %v", err)
+	}
+	if !cfg.TelemetryOn() {
+		t.Error("TelemetryOn() = false after opting in")
+	}
+
+	saved, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !saved.TelemetryOn() {
+		t.Error("saved config has telemetry off, want the answer saved")
+	}
+	defaults := DefaultConfig()
+	if saved.APIURL != defaults.APIURL || saved.OutputFormat != defaults.OutputFormat || saved.TimeFormat != "" {
+		t.Errorf("saved api_url %q, output_format %q, time_format %q, want the settings on disk left alone", saved.APIURL, saved.OutputFormat, saved.TimeFormat)
+	}
+}
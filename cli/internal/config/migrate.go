@@ -0,0 +1,113 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// CurrentSchemaVersion is the config file layout written by this version of the CLI.
+const CurrentSchemaVersion = 1
+
+// migrations[i] upgrades a raw config from schema version i to i+1.
+var migrations = []func(raw map[string]interface{}) error{
+	migrateV0ToV1,
+}
+
+// MigrationResult describes what Migrate did to a config file.
+type MigrationResult struct {
+	FromVersion int
+	ToVersion   int
+	BackupPath  string
+}
+
+// Migrated reports whether the file was rewritten.
+func (r *MigrationResult) Migrated() bool {
+	return r.FromVersion != r.ToVersion
+}
+
+// Migrate upgrades the config file at configPath to CurrentSchemaVersion. The original file is
+// copied to a timestamped backup next to it before the upgraded config is written.
+func Migrate(configPath string) (*MigrationResult, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	migrated, result, err := migrateData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Migrated() {
+		return result, nil
+	}
+
+	result.BackupPath = fmt.Sprintf("%s.v%d.%s.bak", configPath, result.FromVersion, time.Now().Format("20060102150405"))
+	if err := os.WriteFile(result.BackupPath, data, 0600); err != nil {
+		return nil, fmt.Errorf("failed to back up config file: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, migrated, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write migrated config file: %w", err)
+	}
+
+	return result, nil
+}
+
+// migrateData applies any pending migrations to raw config JSON and returns the upgraded JSON.
+func migrateData(data []byte) ([]byte, *MigrationResult, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	version := 0
+	if v, ok := raw["schema_version"].(float64); ok {
+		version = int(v)
+	}
+
+	result := &MigrationResult{FromVersion: version, ToVersion: version}
+
+	if version > CurrentSchemaVersion {
+		return nil, nil, fmt.Errorf("config file schema version %d is newer than this CLI supports (%d); upgrade the CLI", version, CurrentSchemaVersion)
+	}
+
+	if version == CurrentSchemaVersion {
+		return data, result, nil
+	}
+
+	for v := version; v < CurrentSchemaVersion; v++ {
+		if err := migrations[v](raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to migrate config from version %d to %d: %w", v, v+1, err)
+		}
+	}
+
+	raw["schema_version"] = CurrentSchemaVersion
+	result.ToVersion = CurrentSchemaVersion
+
+	migrated, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return migrated, result, nil
+}
+
+// migrateV0ToV1 converts a timeout written as a duration string (e.g. "10m") into the
+// nanosecond count the config struct decodes.
+func migrateV0ToV1(raw map[string]interface{}) error {
+	timeout, ok := raw["timeout"].(string)
+	if !ok {
+		return nil
+	}
+
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("invalid timeout %q: %w", timeout, err)
+	}
+
+	raw["timeout"] = int64(d)
+	return nil
+}
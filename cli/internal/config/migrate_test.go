@@ -0,0 +1,125 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv(HomeEnv, dir)
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMigrateV0(t *testing.T) {
+	original := `{"api_url":"https://api.example.com","auth_token":"tok","timeout":"10m","output_format":"json"}`
+	path := writeConfigFile(t, original)
+
+	result, err := Migrate(path)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if !result.Migrated() || result.FromVersion != 0 || result.ToVersion != CurrentSchemaVersion {
+		t.Fatalf("Migrate() = %+v, want an upgrade from 0 to %d", result, CurrentSchemaVersion)
+	}
+
+	backup, err := os.ReadFile(result.BackupPath)
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+	if string(backup) != original {
+		t.Errorf("backup = %s, want the original file", backup)
+	}
+	if !strings.HasPrefix(filepath.Base(result.BackupPath), "config.json.v0.") {
+		t.Errorf("backup path = %s, want config.json.v0.<time>.bak", result.BackupPath)
+	}
+
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath() error = %v", err)
+	}
+	if cfg.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("schema version = %d, want %d", cfg.SchemaVersion, CurrentSchemaVersion)
+	}
+	if cfg.Timeout != 10*time.Minute {
+		t.Errorf("timeout = %s, want 10m", cfg.Timeout)
+	}
+	if cfg.APIURL != "https://api.example.com" || cfg.AuthToken != "tok" || cfg.OutputFormat != "json" {
+		t.Errorf("settings not kept: %+v", cfg)
+	}
+}
+
+func TestMigrateOnLoad(t *testing.T) {
+	path := writeConfigFile(t, `{"api_url":"https://api.example.com","timeout":"90s"}`)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Timeout != 90*time.Second {
+		t.Errorf("timeout = %s, want 90s", cfg.Timeout)
+	}
+
+	// The file is upgraded in place, so the next load has nothing to migrate
+	result, err := Migrate(path)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if result.Migrated() {
+		t.Errorf("Migrate() after Load = %+v, want no changes", result)
+	}
+}
+
+func TestMigrateData(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantVersion int
+		wantTimeout interface{}
+		wantErr     string
+	}{
+		{name: "v0 duration string", data: `{"timeout":"5m"}`, wantVersion: CurrentSchemaVersion, wantTimeout: float64(5 * time.Minute)},
+		{name: "v0 numeric timeout", data: `{"timeout":60000000000}`, wantVersion: CurrentSchemaVersion, wantTimeout: float64(time.Minute)},
+		{name: "v0 without timeout", data: `{"api_url":"https://api.example.com"}`, wantVersion: CurrentSchemaVersion},
+		{name: "current version untouched", data: `{"schema_version":1,"timeout":"5m"}`, wantVersion: 1, wantTimeout: "5m"},
+		{name: "invalid timeout", data: `{"timeout":"soon"}`, wantErr: `failed to migrate config from version 0 to 1: invalid timeout "soon"`},
+		{name: "newer version", data: `{"schema_version":99}`, wantErr: "config file schema version 99 is newer than this CLI supports"},
+		{name: "not json", data: `timeout = 5m`, wantErr: "failed to parse config file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrated, result, err := migrateData([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("migrateData() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("migrateData() error = %v", err)
+			}
+			if result.ToVersion != tt.wantVersion {
+				t.Errorf("ToVersion = %d, want %d", result.ToVersion, tt.wantVersion)
+			}
+
+			var raw map[string]interface{}
+			if err := json.Unmarshal(migrated, &raw); err != nil {
+				t.Fatalf("migrated config is not JSON: %v", err)
+			}
+			if raw["timeout"] != tt.wantTimeout {
+				t.Errorf("timeout = %#v, want %#v", raw["timeout"], tt.wantTimeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SharedSettings are the settings that can be exported and handed to teammates. Credentials and
+// machine-specific paths are never part of it.
+type SharedSettings struct {
+	SchemaVersion int    `json:"schema_version"`
+	APIURL        string `json:"api_url,omitempty"`
+	WebURL        string `json:"web_url,omitempty"`
+	OutputFormat  string `json:"output_format,omitempty"`
+	TimeFormat    string `json:"time_format,omitempty"`
+	Timeout       string `json:"timeout,omitempty"`
+
+	FormatOverrides map[string]string `json:"format_overrides,omitempty"`
+}
+
+// secretKeys are config keys that must never be read from or written to a shared settings file.
+var secretKeys = []string{"auth_token", "csrf_token", "encryption_key"}
+
+// SharedSettings returns the shareable subset of the config.
+func (c *Config) SharedSettings() SharedSettings {
+	settings := SharedSettings{
+		SchemaVersion: CurrentSchemaVersion,
+		APIURL:        c.APIURL,
+		WebURL:        c.FrontendURL,
+		OutputFormat:  c.OutputFormat,
+		TimeFormat:    c.TimeFormat,
+
+		FormatOverrides: c.FormatOverrides,
+	}
+	if c.Timeout > 0 {
+		settings.Timeout = c.Timeout.String()
+	}
+	return settings
+}
+
+// ParseSharedSettings decodes a shared settings file. Credential keys are dropped and returned in
+// ignored so the caller can report them; any other unknown key is an error.
+func ParseSharedSettings(data []byte) (settings *SharedSettings, ignored []string, err error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	for _, key := range secretKeys {
+		if _, ok := raw[key]; ok {
+			ignored = append(ignored, key)
+			delete(raw, key)
+		}
+	}
+
+	filtered, err := json.Marshal(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(filtered))
+	decoder.DisallowUnknownFields()
+
+	settings = &SharedSettings{}
+	if err := decoder.Decode(settings); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	if settings.SchemaVersion > CurrentSchemaVersion {
+		return nil, nil, fmt.Errorf("settings schema version %d is newer than this CLI supports (%d); upgrade the CLI", settings.SchemaVersion, CurrentSchemaVersion)
+	}
+
+	if settings.Timeout != "" {
+		if _, err := time.ParseDuration(settings.Timeout); err != nil {
+			return nil, nil, fmt.Errorf("invalid timeout %q: %w", settings.Timeout, err)
+		}
+	}
+
+	for commandPath, format := range settings.FormatOverrides {
+		if format != "table" && format != "json" && format != "yaml" {
+			return nil, nil, fmt.Errorf("invalid output format %q for %s (valid: table, json, yaml)", format, commandPath)
+		}
+	}
+
+	sort.Strings(ignored)
+	return settings, ignored, nil
+}
+
+// ApplySharedSettings merges the non-empty settings into the config and returns the keys that changed.
+// The config is not saved.
+func (c *Config) ApplySharedSettings(settings *SharedSettings) []string {
+	var changed []string
+
+	set := func(key string, current *string, value string) {
+		if value != "" && value != *current {
+			*current = value
+			changed = append(changed, key)
+		}
+	}
+
+	set("api_url", &c.APIURL, settings.APIURL)
+	set("web_url", &c.FrontendURL, settings.WebURL)
+	set("output_format", &c.OutputFormat, settings.OutputFormat)
+	set("time_format", &c.TimeFormat, settings.TimeFormat)
+
+	for commandPath, format := range settings.FormatOverrides {
+		if c.FormatOverrides[commandPath] != format {
+			if c.FormatOverrides == nil {
+				c.FormatOverrides = make(map[string]string)
+			}
+			c.FormatOverrides[commandPath] = format
+			changed = append(changed, "format_overrides."+commandPath)
+		}
+	}
+
+	if settings.Timeout != "" {
+		// ParseSharedSettings has already validated the duration
+		timeout, _ := time.ParseDuration(settings.Timeout)
+		if timeout != c.Timeout {
+			c.Timeout = timeout
+			changed = append(changed, "timeout")
+		}
+	}
+
+	return changed
+}
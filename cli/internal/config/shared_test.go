@@ -0,0 +1,103 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// secretConfig returns a config with every credential and machine-specific field set to a value
+// that is easy to search for.
+func secretConfig() *Config {
+	return &Config{
+		SchemaVersion:   CurrentSchemaVersion,
+		APIURL:          "https://api.example.com",
+		AuthToken:       "secret-auth-token",
+		EncryptionKey:   "secret-encryption-key",
+		CSRFToken:       "secret-csrf-token",
+		CSRFCookie:      "csrf_token",
+		APIKey:          "secret-api-key",
+		OutputFormat:    "json",
+		Timeout:         2 * time.Minute,
+		SSHKeyPath:      "/home/student/.openlabs/keys",
+		TimeFormat:      "utc",
+		FrontendURL:     "https://app.example.com",
+		FormatOverrides: map[string]string{"range.jobs": "yaml"},
+	}
+}
+
+func TestSharedSettingsOmitsSecrets(t *testing.T) {
+	data, err := json.Marshal(secretConfig().SharedSettings())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported := string(data)
+
+	for _, secret := range []string{"secret-", "auth_token", "encryption_key", "csrf", "api_key", "ssh_key_path", "/home/student"} {
+		if strings.Contains(exported, secret) {
+			t.Errorf("export contains %q: %s", secret, exported)
+		}
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"schema_version":   float64(CurrentSchemaVersion),
+		"api_url":          "https://api.example.com",
+		"web_url":          "https://app.example.com",
+		"output_format":    "json",
+		"time_format":      "utc",
+		"timeout":          "2m0s",
+		"format_overrides": map[string]interface{}{"range.jobs": "yaml"},
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("export = %v, want %v", settings, want)
+	}
+}
+
+func TestParseSharedSettingsIgnoresSecrets(t *testing.T) {
+	data := `{"schema_version":1,"api_url":"https://api.example.com","auth_token":"t","encryption_key":"k","csrf_token":"c"}`
+
+	settings, ignored, err := ParseSharedSettings([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseSharedSettings() error = %v", err)
+	}
+	if strings.Join(ignored, ",") != "auth_token,csrf_token,encryption_key" {
+		t.Errorf("ignored = %v, want the credential keys", ignored)
+	}
+
+	cfg := &Config{AuthToken: "mine", EncryptionKey: "my-key"}
+	changed := cfg.ApplySharedSettings(settings)
+	if strings.Join(changed, ",") != "api_url" {
+		t.Errorf("changed = %v, want only api_url", changed)
+	}
+	if cfg.AuthToken != "mine" || cfg.EncryptionKey != "my-key" {
+		t.Errorf("import changed credentials to %q / %q", cfg.AuthToken, cfg.EncryptionKey)
+	}
+}
+
+func TestParseSharedSettingsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "unknown key", data: `{"ssh_key_path":"/tmp/keys"}`, wantErr: "unknown field"},
+		{name: "newer schema", data: `{"schema_version":99}`, wantErr: "newer than this CLI supports"},
+		{name: "bad timeout", data: `{"timeout":"soon"}`, wantErr: `invalid timeout "soon"`},
+		{name: "bad format override", data: `{"format_overrides":{"range.list":"xml"}}`, wantErr: `invalid output format "xml" for range.list`},
+		{name: "not json", data: `api_url: x`, wantErr: "failed to parse settings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ParseSharedSettings([]byte(tt.data)); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseSharedSettings() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// State holds values the CLI remembers between commands, kept apart from user settings in config.json.
+type State struct {
+	LastRangeID int `json:"last_range_id,omitempty"`
+}
+
+func getStatePath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "state.json"), nil
+}
+
+// LoadState reads the saved CLI state. A missing state file yields an empty State.
+func LoadState() (*State, error) {
+	statePath, err := getStatePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(statePath)
+	if os.IsNotExist(err) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+
+	return &state, nil
+}
+
+func (s *State) Save() error {
+	statePath, err := getStatePath()
+	if err != nil {
+		return err
+	}
+
+	if err := ensureConfigDir(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	return os.WriteFile(statePath, data, 0600)
+}
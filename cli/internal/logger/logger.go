@@ -1,8 +1,10 @@
 package logger
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strings"
 )
 
 type Level int
@@ -12,10 +14,30 @@ const (
 	LevelWarn
 	LevelInfo
 	LevelDebug
+	LevelTrace
 )
 
+var levelNames = []string{"error", "warn", "info", "debug", "trace"}
+
+func (l Level) String() string {
+	if l < LevelError || l > LevelTrace {
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel converts a level name such as "debug" to a Level.
+func ParseLevel(name string) (Level, error) {
+	for i, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid log level: %s (valid: %s)", name, strings.Join(levelNames, ", "))
+}
+
 var (
-	currentLevel = LevelInfo
+	currentLevel = LevelWarn
 	logger       = log.New(os.Stderr, "", log.LstdFlags)
 )
 
@@ -24,12 +46,16 @@ func SetLevel(level Level) {
 	currentLevel = level
 }
 
-// SetDebug is a convenience function to enable debug logging
-func SetDebug(enabled bool) {
-	if enabled {
-		SetLevel(LevelDebug)
-	} else {
-		SetLevel(LevelInfo)
+// Enabled reports whether messages at level are currently logged, so callers can skip building
+// expensive messages.
+func Enabled(level Level) bool {
+	return currentLevel >= level
+}
+
+// Trace logs a trace message, used for full request and response bodies
+func Trace(format string, args ...interface{}) {
+	if currentLevel >= LevelTrace {
+		logger.Printf("[TRACE] "+format, args...)
 	}
 }
 
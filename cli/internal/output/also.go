@@ -0,0 +1,72 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/utils"
+)
+
+// alsoTargets are the files the result is written to in addition to stdout, set by --also-json and
+// --also-yaml.
+var alsoTargets []alsoTarget
+
+type alsoTarget struct {
+	flag   string
+	format string
+	path   string
+}
+
+// SetAlsoWrite makes Display also write its result as JSON to jsonPath and as YAML to yamlPath. An
+// empty path leaves that format out.
+func SetAlsoWrite(jsonPath, yamlPath string) {
+	alsoTargets = nil
+	if jsonPath != "" {
+		alsoTargets = append(alsoTargets, alsoTarget{flag: "--also-json", format: "json", path: jsonPath})
+	}
+	if yamlPath != "" {
+		alsoTargets = append(alsoTargets, alsoTarget{flag: "--also-yaml", format: "yaml", path: yamlPath})
+	}
+}
+
+// WriteAlso writes data to the --also-json and --also-yaml files. Display calls it after printing;
+// commands that print their own table layout instead of calling Display call it with the result
+// the layout shows. Every file is attempted, and nothing is written to stdout.
+func WriteAlso(data interface{}) error {
+	var errs []error
+	for _, target := range alsoTargets {
+		if err := target.write(data); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write %s file %s: %w", target.flag, target.path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// write formats data and replaces the target file with it. The file is written to a temporary file
+// first, so a failure leaves any previous contents intact, and is always readable by the owner only.
+func (t alsoTarget) write(data interface{}) error {
+	formatted, err := NewFormatter(t.format).Format(data)
+	if err != nil {
+		return err
+	}
+
+	path := utils.ExpandPath(t.path)
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(strings.TrimRight(formatted, "\n") + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -1,11 +1,16 @@
 package output
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/color"
 )
 
 type Formatter interface {
@@ -47,7 +52,27 @@ func (f *TableFormatter) Format(data interface{}) (string, error) {
 	return formatAsTable(data)
 }
 
+// Display prints data to stdout in format, then writes it to any --also-json and --also-yaml files.
 func Display(data interface{}, format string) error {
+	if err := display(data, format); err != nil {
+		return err
+	}
+	return WriteAlso(data)
+}
+
+func display(data interface{}, format string) error {
+	if QueryEnabled() {
+		value, err := evaluateQuery(data, query)
+		if err != nil {
+			return err
+		}
+		return writeQueryResult(os.Stdout, value)
+	}
+
+	if format == "json" && isStreamableSlice(data) {
+		return streamJSONArray(os.Stdout, reflect.ValueOf(data))
+	}
+
 	formatter := NewFormatter(format)
 	output, err := formatter.Format(data)
 	if err != nil {
@@ -58,6 +83,56 @@ func Display(data interface{}, format string) error {
 	return nil
 }
 
+// isStreamableSlice reports whether data is a slice that encoding/json would render as a plain array,
+// unlike []byte or a slice type with its own MarshalJSON.
+func isStreamableSlice(data interface{}) bool {
+	val := reflect.ValueOf(data)
+	if val.Kind() != reflect.Slice || val.IsNil() || val.Type().Elem().Kind() == reflect.Uint8 {
+		return false
+	}
+
+	_, customMarshaler := data.(json.Marshaler)
+	return !customMarshaler
+}
+
+// streamJSONArray writes a slice as an indented JSON array one element at a time, so large lists are
+// never held in memory as a single formatted string. The output matches JSONFormatter byte for byte.
+func streamJSONArray(w io.Writer, val reflect.Value) error {
+	if val.Len() == 0 {
+		_, err := fmt.Fprint(w, "[]")
+		return err
+	}
+
+	buf := bufio.NewWriter(w)
+
+	if _, err := buf.WriteString("[\n  "); err != nil {
+		return err
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		if i > 0 {
+			if _, err := buf.WriteString(",\n  "); err != nil {
+				return err
+			}
+		}
+
+		element, err := json.MarshalIndent(val.Index(i).Interface(), "  ", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format as JSON: %w", err)
+		}
+
+		if _, err := buf.Write(element); err != nil {
+			return err
+		}
+	}
+
+	if _, err := buf.WriteString("\n]"); err != nil {
+		return err
+	}
+
+	return buf.Flush()
+}
+
 func DisplayError(err error) {
-	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	fmt.Fprintf(os.Stderr, "%s %v\n", color.Red("Error:"), err)
 }
@@ -0,0 +1,179 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// query is the --query path applied by Display; empty means the whole result is printed.
+var query []querySegment
+
+// querySegment is one step of a query path: an object key, or an array index when key is empty.
+type querySegment struct {
+	key   string
+	index int
+}
+
+func (s querySegment) String() string {
+	if s.key == "" {
+		return fmt.Sprintf("[%d]", s.index)
+	}
+	return "." + s.key
+}
+
+// SetQuery makes Display print only the value at path, such as vpcs[0].subnets[0].cidr.
+func SetQuery(path string) error {
+	segments, err := parseQuery(path)
+	if err != nil {
+		return err
+	}
+	query = segments
+	return nil
+}
+
+// QueryEnabled reports whether a --query path is set.
+func QueryEnabled() bool {
+	return len(query) > 0
+}
+
+// IsTable reports whether output in format is a table. With --query set, Display prints the queried
+// part of the JSON result whatever the format, so commands with their own table layout check this
+// rather than the format alone.
+func IsTable(format string) bool {
+	return format == "table" && !QueryEnabled()
+}
+
+// parseQuery splits a path of dotted keys and bracketed indexes into segments. A leading dot is allowed.
+func parseQuery(path string) ([]querySegment, error) {
+	var segments []querySegment
+
+	rest := strings.TrimPrefix(path, ".")
+	if rest == "" {
+		return nil, nil
+	}
+
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid query %q: missing ]", path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid query %q: %q is not an array index", path, rest[1:end])
+			}
+			segments = append(segments, querySegment{index: index})
+			rest = rest[end+1:]
+
+		case rest[0] == '.' && len(segments) > 0:
+			rest = rest[1:]
+			if rest == "" || rest[0] == '.' || rest[0] == '[' {
+				return nil, fmt.Errorf("invalid query %q: empty key", path)
+			}
+
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid query %q: empty key", path)
+			}
+			segments = append(segments, querySegment{key: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+
+	return segments, nil
+}
+
+// evaluateQuery resolves segments against data after converting it to plain JSON values, so the keys
+// are the same ones --format json prints.
+func evaluateQuery(data interface{}, segments []querySegment) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate query: %w", err)
+	}
+
+	var current interface{}
+	if err := json.Unmarshal(encoded, &current); err != nil {
+		return nil, fmt.Errorf("failed to evaluate query: %w", err)
+	}
+
+	resolved := ""
+	for _, segment := range segments {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			if segment.key == "" {
+				return nil, fmt.Errorf("query: %s is an object, not an array", displayPath(resolved))
+			}
+			next, ok := value[segment.key]
+			if !ok {
+				return nil, fmt.Errorf("query: %s has no key %q", displayPath(resolved), segment.key)
+			}
+			current = next
+
+		case []interface{}:
+			if segment.key != "" {
+				return nil, fmt.Errorf("query: %s is an array, use an index such as [0] instead of .%s", displayPath(resolved), segment.key)
+			}
+			if segment.index >= len(value) {
+				return nil, fmt.Errorf("query: index %d is out of range for %s (length %d)", segment.index, displayPath(resolved), len(value))
+			}
+			current = value[segment.index]
+
+		default:
+			return nil, fmt.Errorf("query: %s is a %s and has no %s", displayPath(resolved), jsonKind(current), segment)
+		}
+
+		resolved += segment.String()
+	}
+
+	return current, nil
+}
+
+func displayPath(resolved string) string {
+	if resolved == "" {
+		return "the result"
+	}
+	return strings.TrimPrefix(resolved, ".")
+}
+
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "value"
+}
+
+// writeQueryResult prints strings and numbers bare, so they can be used directly in scripts, and
+// everything else as indented JSON.
+func writeQueryResult(w io.Writer, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		_, err := fmt.Fprintln(w, v)
+		return err
+	case float64, bool, nil:
+		encoded, _ := json.Marshal(v)
+		_, err := fmt.Fprintln(w, string(encoded))
+		return err
+	}
+
+	encoded, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format as JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(encoded))
+	return err
+}
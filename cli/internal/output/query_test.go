@@ -0,0 +1,217 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{path: "", want: ""},
+		{path: ".", want: ""},
+		{path: "name", want: ".name"},
+		{path: ".name", want: ".name"},
+		{path: "vpcs[0].subnets[12].cidr", want: ".vpcs[0].subnets[12].cidr"},
+		{path: "[1]", want: "[1]"},
+		{path: "[0][1]", want: "[0][1]"},
+		{path: "vpcs[0", wantErr: "missing ]"},
+		{path: "vpcs[]", wantErr: `"" is not an array index`},
+		{path: "vpcs[-1]", wantErr: `"-1" is not an array index`},
+		{path: "vpcs[first]", wantErr: `"first" is not an array index`},
+		{path: "vpcs..name", wantErr: "empty key"},
+		{path: "vpcs.", wantErr: "empty key"},
+		{path: "vpcs.[0]", wantErr: "empty key"},
+		{path: "..name", wantErr: "empty key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			segments, err := parseQuery(tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseQuery(%q) error = %v, want it to contain %q", tt.path, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseQuery(%q) error = %v", tt.path, err)
+			}
+
+			var got strings.Builder
+			for _, segment := range segments {
+				got.WriteString(segment.String())
+			}
+			if got.String() != tt.want {
+				t.Errorf("parseQuery(%q) = %s, want %s", tt.path, got.String(), tt.want)
+			}
+		})
+	}
+}
+
+type querySubnet struct {
+	Name string `json:"name"`
+	CIDR string `json:"cidr"`
+}
+
+type queryVPC struct {
+	Name    string        `json:"name"`
+	Subnets []querySubnet `json:"subnets"`
+}
+
+type queryRange struct {
+	ID      int               `json:"id"`
+	Name    string            `json:"name"`
+	Public  bool              `json:"is_public"`
+	Owner   *string           `json:"owner"`
+	VPCs    []queryVPC        `json:"vpcs"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+func TestEvaluateQuery(t *testing.T) {
+	data := queryRange{
+		ID:   7,
+		Name: "lab",
+		VPCs: []queryVPC{
+			{Name: "main", Subnets: []querySubnet{{Name: "dmz", CIDR: "10.0.1.0/24"}, {Name: "lan", CIDR: "10.0.2.0/24"}}},
+		},
+		Details: map[string]string{"region": "us_east_1"},
+	}
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{path: "name", want: "lab\n"},
+		{path: "id", want: "7\n"},
+		{path: "is_public", want: "false\n"},
+		{path: "owner", want: "null\n"},
+		{path: "vpcs[0].subnets[1].cidr", want: "10.0.2.0/24\n"},
+		{path: "details.region", want: "us_east_1\n"},
+		{path: "vpcs[0].subnets[0]", want: "{\n  \"cidr\": \"10.0.1.0/24\",\n  \"name\": \"dmz\"\n}\n"},
+		{path: "", want: ""},
+		{path: "Name", wantErr: `query: the result has no key "Name"`},
+		{path: "vpcs[0].gateway", wantErr: `query: vpcs[0] has no key "gateway"`},
+		{path: "vpcs[3]", wantErr: "query: index 3 is out of range for vpcs (length 1)"},
+		{path: "vpcs.name", wantErr: "query: vpcs is an array, use an index such as [0] instead of .name"},
+		{path: "details[0]", wantErr: "query: details is an object, not an array"},
+		{path: "name.first", wantErr: "query: name is a string and has no .first"},
+		{path: "id[0]", wantErr: "query: id is a number and has no [0]"},
+		{path: "owner.name", wantErr: "query: owner is a null and has no .name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			segments, err := parseQuery(tt.path)
+			if err != nil {
+				t.Fatalf("parseQuery(%q) error = %v", tt.path, err)
+			}
+
+			value, err := evaluateQuery(data, segments)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("evaluateQuery(%q) error = %v, want %q", tt.path, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("evaluateQuery(%q) error = %v", tt.path, err)
+			}
+
+			if tt.path == "" {
+				// An empty query resolves to the whole result
+				if _, ok := value.(map[string]interface{}); !ok {
+					t.Errorf("evaluateQuery(%q) = %v, want the whole result", tt.path, value)
+				}
+				return
+			}
+
+			var out bytes.Buffer
+			if err := writeQueryResult(&out, value); err != nil {
+				t.Fatalf("writeQueryResult() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("query %q printed %q, want %q", tt.path, out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateQueryTopLevelArray(t *testing.T) {
+	data := []queryVPC{{Name: "main"}, {Name: "backup"}}
+
+	segments, err := parseQuery("[1].name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := evaluateQuery(data, segments)
+	if err != nil {
+		t.Fatalf("evaluateQuery() error = %v", err)
+	}
+	if value != "backup" {
+		t.Errorf("evaluateQuery() = %v, want backup", value)
+	}
+
+	segments, _ = parseQuery("name")
+	if _, err := evaluateQuery(data, segments); err == nil || !strings.Contains(err.Error(), "the result is an array") {
+		t.Errorf("evaluateQuery() error = %v, want the result is an array", err)
+	}
+}
+
+// setQuery sets --query for the rest of the test.
+func setQuery(t *testing.T, path string) {
+	t.Helper()
+
+	if err := SetQuery(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { query = nil })
+}
+
+func TestIsTable(t *testing.T) {
+	if !IsTable("table") || IsTable("json") || IsTable("yaml") {
+		t.Error("IsTable() without a query does not follow the format")
+	}
+
+	setQuery(t, "name")
+	if IsTable("table") {
+		t.Error("IsTable(table) = true with a query set, want the query result printed instead")
+	}
+	if DefaultTimeFormat("table") != TimeFormatRFC3339 {
+		t.Errorf("DefaultTimeFormat(table) = %s with a query set, want %s", DefaultTimeFormat("table"), TimeFormatRFC3339)
+	}
+}
+
+func TestDisplayQueryWithAnyFormat(t *testing.T) {
+	setQuery(t, "[1].name")
+	data := []map[string]string{{"name": "web-lab"}, {"name": "ad-lab"}}
+
+	for _, format := range []string{"table", "json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			stdout := os.Stdout
+			os.Stdout = w
+			err = Display(data, format)
+			os.Stdout = stdout
+			w.Close()
+
+			if err != nil {
+				t.Fatalf("Display() error = %v", err)
+			}
+			got, _ := io.ReadAll(r)
+			if string(got) != "ad-lab\n" {
+				t.Errorf("Display(%s) printed %q, want the queried value", format, got)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package output
 
 import (
 	"fmt"
+	"os"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -36,15 +38,19 @@ func formatSliceAsTable(val reflect.Value) (string, error) {
 		return "No data available\n", nil
 	}
 
-	firstItem := val.Index(0)
-	if firstItem.Kind() == reflect.Ptr {
-		firstItem = firstItem.Elem()
-	}
+	firstItem := indirectValue(val.Index(0))
 
 	if firstItem.Kind() != reflect.Struct {
+		if !isHomogeneousSlice(val, firstItem) {
+			return formatRaggedSlice(val)
+		}
 		return formatSimpleSlice(val), nil
 	}
 
+	if !isHomogeneousSlice(val, firstItem) {
+		return formatRaggedSlice(val)
+	}
+
 	var buf strings.Builder
 	table := tablewriter.NewWriter(&buf)
 
@@ -52,18 +58,66 @@ func formatSliceAsTable(val reflect.Value) (string, error) {
 	table.SetHeader(headers)
 
 	for i := 0; i < val.Len(); i++ {
-		item := val.Index(i)
-		if item.Kind() == reflect.Ptr {
-			item = item.Elem()
-		}
-		row := extractStructValues(item)
+		row := extractStructValues(indirectValue(val.Index(i)))
 		table.Append(row)
 	}
 
+	if showTotals {
+		table.SetFooter(extractStructTotals(firstItem.Type(), val))
+	}
+
 	table.Render()
 	return buf.String(), nil
 }
 
+// indirectValue unwraps pointers and interfaces, such as the elements of an []interface{}.
+func indirectValue(val reflect.Value) reflect.Value {
+	for val.IsValid() && (val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface) {
+		if val.IsNil() {
+			return reflect.Value{}
+		}
+		val = val.Elem()
+	}
+	return val
+}
+
+// isHomogeneousSlice reports whether every element of a slice has the same underlying type as first.
+// A slice of structs mixed with other types cannot share one set of table columns.
+func isHomogeneousSlice(val reflect.Value, first reflect.Value) bool {
+	if !first.IsValid() {
+		return false
+	}
+
+	firstIsStruct := first.Kind() == reflect.Struct
+	for i := 1; i < val.Len(); i++ {
+		item := indirectValue(val.Index(i))
+		if !item.IsValid() {
+			if firstIsStruct {
+				return false
+			}
+			continue
+		}
+		if firstIsStruct && item.Type() != first.Type() {
+			return false
+		}
+		if !firstIsStruct && item.Kind() == reflect.Struct {
+			return false
+		}
+	}
+	return true
+}
+
+// formatRaggedSlice renders a slice whose elements don't share a shape as JSON, since a table would be
+// misaligned.
+func formatRaggedSlice(val reflect.Value) (string, error) {
+	fmt.Fprintln(os.Stderr, "Note: items have different shapes, showing JSON instead of a table")
+	formatted, err := (&JSONFormatter{}).Format(val.Interface())
+	if err != nil {
+		return "", err
+	}
+	return formatted + "\n", nil
+}
+
 func formatStructAsTable(val reflect.Value) (string, error) {
 	var buf strings.Builder
 	table := tablewriter.NewWriter(&buf)
@@ -153,7 +207,7 @@ func formatFieldValue(val reflect.Value) string {
 	}
 
 	switch val.Kind() {
-	case reflect.Ptr:
+	case reflect.Ptr, reflect.Interface:
 		if val.IsNil() {
 			return ""
 		}
@@ -174,13 +228,20 @@ func formatFieldValue(val reflect.Value) string {
 			items = append(items, formatFieldValue(val.Index(i)))
 		}
 		return strings.Join(items, ", ")
+	case reflect.Map:
+		var items []string
+		for _, key := range val.MapKeys() {
+			items = append(items, fmt.Sprintf("%v=%s", key.Interface(), formatFieldValue(val.MapIndex(key))))
+		}
+		sort.Strings(items)
+		return strings.Join(items, ", ")
 	case reflect.Struct:
 		if val.Type() == reflect.TypeOf(time.Time{}) {
 			t := val.Interface().(time.Time)
 			if t.IsZero() {
 				return ""
 			}
-			return t.Format("2006-01-02 15:04:05")
+			return FormatTime(t)
 		}
 		return fmt.Sprintf("%v", val.Interface())
 	default:
@@ -0,0 +1,68 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type tableHost struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+type tableRange struct {
+	ID    int    `json:"id"`
+	State string `json:"state"`
+}
+
+func TestFormatSliceAsTable(t *testing.T) {
+	hostA, hostB := &tableHost{Name: "web", Size: 8}, &tableHost{Name: "db", Size: 16}
+
+	tests := []struct {
+		name     string
+		data     interface{}
+		wantJSON bool
+		contains []string
+	}{
+		{name: "empty", data: []tableHost{}, contains: []string{"No data available"}},
+		{name: "structs", data: []tableHost{{Name: "web", Size: 8}, {Name: "db", Size: 16}}, contains: []string{"NAME", "SIZE", "web", "16"}},
+		{name: "struct pointers", data: []*tableHost{hostA, hostB}, contains: []string{"NAME", "web", "db"}},
+		{name: "structs in interface slice", data: []interface{}{tableHost{Name: "web"}, &tableHost{Name: "db"}}, contains: []string{"NAME", "web", "db"}},
+		{name: "scalars", data: []string{"us_east_1", "us_east_2"}, contains: []string{"us_east_1\nus_east_2\n"}},
+		{name: "mixed scalars", data: []interface{}{"a", 1, true}, contains: []string{"a\n1\ntrue\n"}},
+		{name: "scalars with nil", data: []interface{}{"a", nil}, contains: []string{"a\n<nil>\n"}},
+		{name: "different struct types", data: []interface{}{tableHost{Name: "web"}, tableRange{ID: 1, State: "on"}}, wantJSON: true},
+		{name: "structs with nil", data: []*tableHost{hostA, nil}, wantJSON: true},
+		{name: "nil first", data: []*tableHost{nil, hostA}, wantJSON: true},
+		{name: "struct then scalar", data: []interface{}{tableHost{Name: "web"}, "db"}, wantJSON: true},
+		{name: "scalar then struct", data: []interface{}{"db", tableHost{Name: "web"}}, wantJSON: true},
+		{name: "maps", data: []interface{}{map[string]interface{}{"a": 1}, map[string]interface{}{"b": 2}}, contains: []string{"map[a:1]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatAsTable(tt.data)
+			if err != nil {
+				t.Fatalf("formatAsTable() error = %v", err)
+			}
+
+			if tt.wantJSON {
+				var decoded []interface{}
+				if err := json.Unmarshal([]byte(got), &decoded); err != nil {
+					t.Fatalf("formatAsTable() = %q, want JSON for ragged data: %v", got, err)
+				}
+				if len(decoded) != 2 {
+					t.Errorf("JSON has %d items, want 2", len(decoded))
+				}
+				return
+			}
+
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("formatAsTable() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	TimeFormatLocal   = "local"
+	TimeFormatUTC     = "utc"
+	TimeFormatRFC3339 = "rfc3339"
+)
+
+var timeFormat = TimeFormatLocal
+
+// ValidateTimeFormat checks that format is one of the supported timestamp styles.
+func ValidateTimeFormat(format string) error {
+	switch format {
+	case TimeFormatLocal, TimeFormatUTC, TimeFormatRFC3339:
+		return nil
+	}
+	return fmt.Errorf("invalid time format: %s (valid: local, utc, rfc3339)", format)
+}
+
+// SetTimeFormat sets how FormatTime renders timestamps.
+func SetTimeFormat(format string) error {
+	if err := ValidateTimeFormat(format); err != nil {
+		return err
+	}
+	timeFormat = format
+	return nil
+}
+
+// DefaultTimeFormat picks the timestamp style for an output format when none is configured:
+// local time for tables read by people, RFC3339 for everything else.
+func DefaultTimeFormat(outputFormat string) string {
+	if IsTable(outputFormat) {
+		return TimeFormatLocal
+	}
+	return TimeFormatRFC3339
+}
+
+// FormatTime renders t using the configured timestamp style.
+func FormatTime(t time.Time) string {
+	switch timeFormat {
+	case TimeFormatUTC:
+		return t.UTC().Format("2006-01-02 15:04:05 UTC")
+	case TimeFormatRFC3339:
+		return t.Format(time.RFC3339)
+	default:
+		return t.Local().Format("2006-01-02 15:04:05")
+	}
+}
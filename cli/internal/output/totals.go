@@ -0,0 +1,115 @@
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// showTotals adds a footer of column aggregates to tables rendered from slices of structs.
+var showTotals bool
+
+// SetTotals enables or disables the totals footer on list tables.
+func SetTotals(enabled bool) {
+	showTotals = enabled
+}
+
+// Column aggregations, selectable per field with a `total:"..."` struct tag.
+const (
+	totalSum   = "sum"
+	totalCount = "count"
+	totalNone  = "none"
+)
+
+// fieldAggregation returns how a column is totalled: numeric columns are summed, timestamps are
+// skipped, and every other column counts the rows with a non-empty value.
+func fieldAggregation(field reflect.StructField) string {
+	if tag := field.Tag.Get("total"); tag != "" {
+		return tag
+	}
+
+	typ := field.Type
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == reflect.TypeOf(time.Time{}) {
+		return totalNone
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return totalSum
+	}
+	return totalCount
+}
+
+// extractStructTotals returns the footer row for a slice of structs of type typ. The first cell
+// holds the row count.
+func extractStructTotals(typ reflect.Type, val reflect.Value) []string {
+	var totals []string
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if len(totals) == 0 {
+			totals = append(totals, fmt.Sprintf("Total: %d", val.Len()))
+			continue
+		}
+
+		totals = append(totals, aggregateColumn(val, i, fieldAggregation(field)))
+	}
+
+	return totals
+}
+
+func aggregateColumn(val reflect.Value, fieldIndex int, aggregation string) string {
+	var (
+		intSum   int64
+		floatSum float64
+		isFloat  bool
+		count    int
+	)
+
+	for i := 0; i < val.Len(); i++ {
+		field := indirectValue(indirectValue(val.Index(i)).Field(fieldIndex))
+		if !field.IsValid() {
+			continue
+		}
+
+		switch aggregation {
+		case totalSum:
+			switch field.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				intSum += field.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				intSum += int64(field.Uint())
+			case reflect.Float32, reflect.Float64:
+				floatSum += field.Float()
+				isFloat = true
+			}
+		case totalCount:
+			if !field.IsZero() && !(field.Kind() == reflect.Slice && field.Len() == 0) {
+				count++
+			}
+		}
+	}
+
+	switch aggregation {
+	case totalSum:
+		if isFloat {
+			return strconv.FormatFloat(floatSum+float64(intSum), 'f', -1, 64)
+		}
+		return strconv.FormatInt(intSum, 10)
+	case totalCount:
+		return strconv.Itoa(count)
+	}
+	// tablewriter drops the borders around empty footer cells
+	return "-"
+}
@@ -1,82 +1,145 @@
 package progress
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/OpenLabsHQ/OpenLabs/cli/internal/client"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
 )
 
 type JobTracker struct {
-	client  *client.Client
-	spinner *Spinner
+	client     *client.Client
+	spinner    *Spinner
+	followLogs bool
+	logOffset  int
+	probe      ProgressProbe
+	probeLabel string
 }
 
+// ProgressProbe reports how many of a job's units of work are done. ok is false when the count
+// is not available yet, in which case the tracker shows its usual status message.
+type ProgressProbe func() (done, total int, ok bool)
+
 func NewJobTracker(c *client.Client) *JobTracker {
 	return &JobTracker{
 		client: c,
 	}
 }
 
+// FollowLogs makes TrackJob print the job's log output while it tracks status.
+func (jt *JobTracker) FollowLogs() {
+	jt.followLogs = true
+}
+
+// ReportProgress makes TrackJob show a progress bar from probe while the job is in progress. label
+// is a format string that receives the done and total counts, such as "provisioned %d/%d hosts".
+func (jt *JobTracker) ReportProgress(label string, probe ProgressProbe) {
+	jt.probeLabel = label
+	jt.probe = probe
+}
+
 func (jt *JobTracker) TrackJob(jobID, initialMessage string, timeout time.Duration) (*client.Job, error) {
 	jt.spinner = NewSpinner(initialMessage)
 	jt.spinner.Start()
 	defer jt.spinner.Stop()
 
-	ticker := time.NewTicker(3 * time.Second)
-	defer ticker.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	timer := time.NewTimer(timeout)
-	defer timer.Stop()
+	poller := jt.client.NewJobPoller(jobID, 3*time.Second)
+	// Streaming logs needs regular checks, so there is nothing to gain from long-polling; progress
+	// only needs refreshing now and then
+	if jt.followLogs {
+		poller.DisableLongPoll()
+	} else if jt.probe != nil {
+		poller.LimitWait(15 * time.Second)
+	}
 
 	lastStatus := ""
 
 	for {
-		select {
-		case <-ticker.C:
-			job, err := jt.client.GetJob(jobID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to check job status: %w", err)
-			}
-
-			if job.Status != lastStatus {
-				jt.updateSpinnerMessage(job)
-				lastStatus = job.Status
+		job, err := poller.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				jt.spinner.Stop()
+				ShowError(fmt.Sprintf("Job timeout after %v (ID: %s)", timeout, jobID))
+				return nil, fmt.Errorf("job timeout after %v", timeout)
 			}
+			return nil, fmt.Errorf("failed to check job status: %w", err)
+		}
 
-			switch job.Status {
-			case "complete":
-				jt.spinner.Stop()
-				ShowSuccess(fmt.Sprintf("Job completed successfully (ID: %s)", jobID))
-				return job, nil
+		if job.Status != lastStatus {
+			jt.updateSpinnerMessage(job)
+			lastStatus = job.Status
+		}
 
-			case "failed":
-				jt.spinner.Stop()
-				errorMsg := "Job failed"
-				if job.ErrorMessage != "" {
-					errorMsg = fmt.Sprintf("Job failed: %s", job.ErrorMessage)
-				}
-				ShowError(fmt.Sprintf("%s (ID: %s)", errorMsg, jobID))
-				return job, fmt.Errorf("%s", errorMsg)
+		if jt.followLogs {
+			jt.printNewLogLines(jobID)
+		}
 
-			case "queued":
-				continue
+		if jt.probe != nil && job.Status == "in_progress" {
+			jt.updateProgress(job)
+		}
 
-			case "in_progress":
-				continue
+		switch job.Status {
+		case "complete":
+			jt.spinner.Stop()
+			ShowSuccess(fmt.Sprintf("Job completed successfully (ID: %s)", jobID))
+			return job, nil
 
-			default:
-				jt.spinner.Stop()
-				ShowError(fmt.Sprintf("Unknown job status: %s (ID: %s)", job.Status, jobID))
-				return job, fmt.Errorf("unknown job status: %s", job.Status)
+		case "failed":
+			jt.spinner.Stop()
+			errorMsg := "Job failed"
+			if job.ErrorMessage != "" {
+				errorMsg = fmt.Sprintf("Job failed: %s", job.ErrorMessage)
 			}
+			ShowError(fmt.Sprintf("%s (ID: %s)", errorMsg, jobID))
+			return job, fmt.Errorf("%s", errorMsg)
+
+		case "queued":
+			continue
 
-		case <-timer.C:
+		case "in_progress":
+			continue
+
+		default:
 			jt.spinner.Stop()
-			ShowError(fmt.Sprintf("Job timeout after %v (ID: %s)", timeout, jobID))
-			return nil, fmt.Errorf("job timeout after %v", timeout)
+			ShowError(fmt.Sprintf("Unknown job status: %s (ID: %s)", job.Status, jobID))
+			return job, fmt.Errorf("unknown job status: %s", job.Status)
+		}
+	}
+}
+
+func (jt *JobTracker) printNewLogLines(jobID string) {
+	logs, err := jt.client.GetJobLogs(jobID, jt.logOffset)
+	if err != nil {
+		if errors.Is(err, client.ErrNotSupported) {
+			jt.spinner.Println("Job logs are not available from this server; tracking status only")
+		} else {
+			logger.Debug("Failed to fetch job logs: %v", err)
+			jt.spinner.Println("Failed to fetch job logs; tracking status only")
 		}
+		jt.followLogs = false
+		return
+	}
+
+	for _, line := range logs.Lines {
+		jt.spinner.Println(line)
+	}
+	jt.logOffset = logs.NextOffset
+}
+
+// updateProgress replaces the spinner message with a progress bar when the probe has a count.
+func (jt *JobTracker) updateProgress(job *client.Job) {
+	done, total, ok := jt.probe()
+	if !ok || total <= 0 {
+		return
 	}
+	label := fmt.Sprintf(jt.probeLabel, done, total)
+	jt.spinner.UpdateMessage(fmt.Sprintf("%s %s (ID: %s)", Bar(done, total, 20), label, job.ARQJobID))
 }
 
 func (jt *JobTracker) updateSpinnerMessage(job *client.Job) {
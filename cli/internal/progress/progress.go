@@ -2,7 +2,11 @@ package progress
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/color"
 )
 
 type Spinner struct {
@@ -11,6 +15,7 @@ type Spinner struct {
 	index     int
 	done      chan bool
 	isRunning bool
+	mu        sync.Mutex
 }
 
 func NewSpinner(message string) *Spinner {
@@ -41,9 +46,18 @@ func (s *Spinner) Stop() {
 }
 
 func (s *Spinner) UpdateMessage(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.message = message
 }
 
+// Println prints a line above the spinner without disturbing it.
+func (s *Spinner) Println(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Printf("\r\033[K%s\n", line)
+}
+
 func (s *Spinner) spin() {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
@@ -53,18 +67,32 @@ func (s *Spinner) spin() {
 		case <-s.done:
 			return
 		case <-ticker.C:
+			s.mu.Lock()
 			fmt.Printf("\r%c %s", s.chars[s.index], s.message)
 			s.index = (s.index + 1) % len(s.chars)
+			s.mu.Unlock()
 		}
 	}
 }
 
+// Bar renders done out of total as a fixed-width text bar, e.g. [#####-----].
+func Bar(done, total, width int) string {
+	if total <= 0 {
+		return "[" + strings.Repeat("-", width) + "]"
+	}
+	if done > total {
+		done = total
+	}
+	filled := done * width / total
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
+}
+
 func ShowSuccess(message string) {
-	fmt.Printf("✓ %s\n", message)
+	fmt.Printf("%s %s\n", color.Green("✓"), message)
 }
 
 func ShowError(message string) {
-	fmt.Printf("✗ %s\n", message)
+	fmt.Printf("%s %s\n", color.Red("✗"), message)
 }
 
 func ShowInfo(message string) {
@@ -72,5 +100,33 @@ func ShowInfo(message string) {
 }
 
 func ShowWarning(message string) {
-	fmt.Printf("⚠ %s\n", message)
+	fmt.Printf("%s %s\n", color.Yellow("⚠"), message)
+}
+
+// WithSpinner shows a spinner with message while fn runs and clears it before returning fn's error,
+// including when fn panics. Reporting the outcome is left to the caller; see WithSpinnerReport.
+func WithSpinner(message string, fn func() error) error {
+	spinner := NewSpinner(message)
+	spinner.Start()
+	defer spinner.Stop()
+
+	return fn()
+}
+
+// WithSpinnerReport runs fn like WithSpinner and reports the outcome: the success message fn returns,
+// or failure when fn fails. fn's error is still returned for the caller to handle.
+func WithSpinnerReport(message, failure string, fn func() (success string, err error)) error {
+	var success string
+	err := WithSpinner(message, func() error {
+		var err error
+		success, err = fn()
+		return err
+	})
+	if err != nil {
+		ShowError(failure)
+		return err
+	}
+
+	ShowSuccess(success)
+	return nil
 }
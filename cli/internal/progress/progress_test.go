@@ -0,0 +1,132 @@
+package progress
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// captureStdout returns everything fn and anything it leaves running print to stdout, including for
+// settle after fn returns.
+func captureStdout(t *testing.T, settle time.Duration, fn func()) (during, after string) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+
+	fn()
+	// Mark where fn returned, so output written afterwards can be told apart
+	_, _ = w.WriteString("\x00")
+	time.Sleep(settle)
+	w.Close()
+
+	during, after, _ = strings.Cut(<-output, "\x00")
+	return during, after
+}
+
+func TestWithSpinnerStopsOnError(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name  string
+		fn    func() error
+		panic bool
+	}{
+		{name: "error", fn: func() error { time.Sleep(250 * time.Millisecond); return errFailed }},
+		{name: "panic", fn: func() error { time.Sleep(250 * time.Millisecond); panic("boom") }, panic: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			var recovered interface{}
+			during, after := captureStdout(t, 300*time.Millisecond, func() {
+				defer func() { recovered = recover() }()
+				err = WithSpinner("Working...", tt.fn)
+			})
+
+			if tt.panic {
+				if recovered == nil {
+					t.Error("WithSpinner() swallowed the panic")
+				}
+			} else if !errors.Is(err, errFailed) {
+				t.Errorf("WithSpinner() error = %v, want %v", err, errFailed)
+			}
+
+			if !strings.Contains(during, "Working...") {
+				t.Errorf("spinner never drew while fn ran: %q", during)
+			}
+			if !strings.HasSuffix(during, "\r\033[K") {
+				t.Errorf("spinner line not cleared when WithSpinner returned: %q", during)
+			}
+			if after != "" {
+				t.Errorf("spinner kept drawing after WithSpinner returned: %q", after)
+			}
+		})
+	}
+}
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		done, total int
+		want        string
+	}{
+		{done: 0, total: 4, want: "[--------]"},
+		{done: 1, total: 4, want: "[##------]"},
+		{done: 4, total: 4, want: "[########]"},
+		{done: 6, total: 4, want: "[########]"},
+		{done: 1, total: 0, want: "[--------]"},
+	}
+
+	for _, tt := range tests {
+		if got := Bar(tt.done, tt.total, 8); got != tt.want {
+			t.Errorf("Bar(%d, %d, 8) = %q, want %q", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestWithSpinnerReport(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name    string
+		err     error
+		want    string
+		notWant string
+	}{
+		{name: "success", want: "✓ Blueprint 7 created", notWant: "Failed to create blueprint"},
+		{name: "failure", err: errFailed, want: "✗ Failed to create blueprint", notWant: "created"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			during, _ := captureStdout(t, 0, func() {
+				err = WithSpinnerReport("Creating blueprint...", "Failed to create blueprint", func() (string, error) {
+					return "Blueprint 7 created", tt.err
+				})
+			})
+
+			if !errors.Is(err, tt.err) {
+				t.Errorf("WithSpinnerReport() error = %v, want %v", err, tt.err)
+			}
+			if !strings.Contains(during, tt.want) || strings.Contains(during, tt.notWant) {
+				t.Errorf("WithSpinnerReport() printed %q, want %q", during, tt.want)
+			}
+		})
+	}
+}
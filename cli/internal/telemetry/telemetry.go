@@ -0,0 +1,80 @@
+// Package telemetry sends anonymous usage events for users who have opted in. An event holds only
+// the command name, whether it succeeded, how long it took, and the CLI version: never arguments,
+// flags, credentials, or anything that identifies the user or their ranges.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/logger"
+)
+
+// sendTimeout bounds a single send, so a slow endpoint cannot keep the process alive.
+const sendTimeout = 2 * time.Second
+
+// Event is one command run.
+type Event struct {
+	Command    string `json:"command"`
+	Success    bool   `json:"success"`
+	DurationMS int64  `json:"duration_ms"`
+	Version    string `json:"version"`
+}
+
+var pending sync.WaitGroup
+
+// Send posts event in the background when cfg has telemetry enabled, and does nothing otherwise. It
+// never blocks, and failures are only logged.
+func Send(cfg *config.Config, event Event) {
+	if cfg == nil || !cfg.TelemetryOn() {
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	endpoint := cfg.TelemetryEndpoint()
+
+	pending.Add(1)
+	go func() {
+		defer pending.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+		if err != nil {
+			logger.Debug("Failed to build telemetry request: %v", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			logger.Debug("Failed to send telemetry: %v", err)
+			return
+		}
+		resp.Body.Close()
+	}()
+}
+
+// Wait gives events still being sent up to d to finish before the process exits. Sends that take
+// longer are abandoned.
+func Wait(d time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,95 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+)
+
+// newTelemetryServer returns a fake telemetry endpoint and the events it has received.
+func newTelemetryServer(t *testing.T) (*httptest.Server, func() []Event) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var events []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("telemetry body is not an event: %v", err)
+		}
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []Event {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]Event(nil), events...)
+	}
+}
+
+func TestSendDisabled(t *testing.T) {
+	server, received := newTelemetryServer(t)
+	disabled := false
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+	}{
+		{name: "no config", cfg: nil},
+		{name: "never asked", cfg: &config.Config{TelemetryURL: server.URL}},
+		{name: "opted out", cfg: &config.Config{TelemetryURL: server.URL, TelemetryEnabled: &disabled}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Send(tt.cfg, Event{Command: "range list", Success: true})
+			Wait(time.Second)
+
+			if events := received(); len(events) != 0 {
+				t.Errorf("sent %v with telemetry disabled", events)
+			}
+		})
+	}
+}
+
+func TestSendEnabled(t *testing.T) {
+	server, received := newTelemetryServer(t)
+	enabled := true
+	cfg := &config.Config{TelemetryURL: server.URL, TelemetryEnabled: &enabled}
+
+	want := Event{Command: "range deploy", Success: false, DurationMS: 1500, Version: "1.2.3"}
+	Send(cfg, want)
+	Wait(5 * time.Second)
+
+	events := received()
+	if len(events) != 1 || events[0] != want {
+		t.Errorf("received %v, want [%v]", events, want)
+	}
+}
+
+func TestWaitGivesUp(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	enabled := true
+	Send(&config.Config{TelemetryURL: server.URL, TelemetryEnabled: &enabled}, Event{Command: "range list"})
+
+	start := time.Now()
+	Wait(50 * time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait() blocked for %v on a stalled endpoint", elapsed)
+	}
+}
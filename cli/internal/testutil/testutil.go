@@ -0,0 +1,45 @@
+// Package testutil holds the fake API setup shared by the CLI's tests.
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/OpenLabsHQ/OpenLabs/cli/internal/config"
+)
+
+// NewConfig returns a config for a fake API at url, signed in with a test token, printing tables,
+// and skipping discovery and the response cache. The openlabs directory points at a temporary one,
+// so tests neither read nor write the real home.
+func NewConfig(t *testing.T, url string) *config.Config {
+	t.Helper()
+
+	t.Setenv(config.HomeEnv, t.TempDir())
+	return &config.Config{
+		APIURL:      url,
+		Format:      "table",
+		Timeout:     5 * time.Second,
+		AuthToken:   "test-token",
+		NoDiscovery: true,
+		NoCache:     true,
+	}
+}
+
+// FakeAPI starts a fake API served by handler for the rest of the test and returns a config for it,
+// as NewConfig does.
+func FakeAPI(t *testing.T, handler http.HandlerFunc) *config.Config {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewConfig(t, server.URL)
+}
+
+// RespondJSON writes body as a JSON response with the given status.
+func RespondJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body))
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type GCPCredentials struct {
+	ProjectID   string
+	ClientEmail string
+	// JSON is the service account key exactly as read, which is what the API stores
+	JSON   string
+	Source string
+}
+
+type gcpServiceAccountKey struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+}
+
+// DetectGCPCredentials loads the service account key named by GOOGLE_APPLICATION_CREDENTIALS. It
+// returns nil when the variable is not set.
+func DetectGCPCredentials() (*GCPCredentials, error) {
+	path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if path == "" {
+		return nil, nil
+	}
+
+	creds, err := ReadGCPServiceAccountFile(path)
+	if err != nil {
+		return nil, err
+	}
+	creds.Source = "GOOGLE_APPLICATION_CREDENTIALS"
+	return creds, nil
+}
+
+func ReadGCPServiceAccountFile(path string) (*GCPCredentials, error) {
+	data, err := os.ReadFile(ExpandPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account key: %w", err)
+	}
+
+	creds, err := ParseGCPServiceAccount(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	creds.Source = path
+	return creds, nil
+}
+
+// ParseGCPServiceAccount checks that data is a service account key with a project ID and client
+// email.
+func ParseGCPServiceAccount(data []byte) (*GCPCredentials, error) {
+	var key gcpServiceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("service account key is not valid JSON: %w", err)
+	}
+
+	var missing []string
+	if key.ProjectID == "" {
+		missing = append(missing, "project_id")
+	}
+	if key.ClientEmail == "" {
+		missing = append(missing, "client_email")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("service account key is missing %s", strings.Join(missing, " and "))
+	}
+
+	return &GCPCredentials{
+		ProjectID:   key.ProjectID,
+		ClientEmail: key.ClientEmail,
+		JSON:        strings.TrimSpace(string(data)),
+	}, nil
+}
+
+// PromptGCPServiceAccount asks for a service account key file path, or for the key JSON itself. Pasted
+// JSON may span several lines; input is read until it forms a complete JSON value.
+func PromptGCPServiceAccount(prompt string) (*GCPCredentials, error) {
+	fmt.Print(prompt + ": ")
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("service account key cannot be empty")
+	}
+
+	if !strings.HasPrefix(input, "{") {
+		return ReadGCPServiceAccountFile(input)
+	}
+
+	pasted := input
+	for !json.Valid([]byte(pasted)) {
+		line, err := reader.ReadString('\n')
+		pasted += "\n" + strings.TrimRight(line, "\r\n")
+		if err != nil {
+			break
+		}
+	}
+
+	creds, err := ParseGCPServiceAccount([]byte(pasted))
+	if err != nil {
+		return nil, err
+	}
+	creds.Source = "pasted JSON"
+	return creds, nil
+}
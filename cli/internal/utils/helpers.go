@@ -7,8 +7,10 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"golang.org/x/term"
 	"gopkg.in/yaml.v3"
@@ -46,7 +48,7 @@ func ReadFileAsYAML(path string, target interface{}) error {
 		return fmt.Errorf("failed to read file %s: %w", path, err)
 	}
 
-	if err := yaml.Unmarshal(data, target); err != nil {
+	if err := DecodeYAML(data, target); err != nil {
 		return fmt.Errorf("failed to parse YAML from %s: %w", path, err)
 	}
 
@@ -148,11 +150,97 @@ func PromptConfirm(prompt string) (bool, error) {
 	}
 }
 
+// IsInteractive reports whether stdin is attached to a terminal.
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// UniqueName appends a numeric suffix to name until it no longer collides with a name in existing,
+// whose keys are lowercase.
+func UniqueName(name string, existing map[string]bool) string {
+	if !existing[strings.ToLower(name)] {
+		return name
+	}
+
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if !existing[strings.ToLower(candidate)] {
+			return candidate
+		}
+	}
+}
+
+// IsTerminalOutput reports whether stdout is a terminal rather than a pipe or file.
+func IsTerminalOutput() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// SelectFromList shows a numbered menu of options and returns the one the user picks.
+func SelectFromList(prompt string, options []string) (string, error) {
+	index, err := SelectIndex(prompt, options)
+	if err != nil {
+		return "", err
+	}
+	return options[index], nil
+}
+
+// SelectIndex shows a numbered menu of options and returns the index of the one the user picks. It
+// fails without prompting when stdin is not a terminal.
+func SelectIndex(prompt string, options []string) (int, error) {
+	if len(options) == 0 {
+		return 0, fmt.Errorf("no options to select from")
+	}
+
+	if !IsInteractive() {
+		return 0, fmt.Errorf("interactive selection requires a terminal")
+	}
+
+	fmt.Println(prompt + ":")
+	for i, option := range options {
+		fmt.Printf("  %d. %s\n", i+1, option)
+	}
+
+	choice, err := PromptString("Selection number")
+	if err != nil {
+		return 0, err
+	}
+
+	index := 0
+	if _, err := fmt.Sscanf(choice, "%d", &index); err != nil || index < 1 || index > len(options) {
+		return 0, fmt.Errorf("invalid selection: %s", choice)
+	}
+
+	return index - 1, nil
+}
+
+// BackupPath returns a timestamped JSON file path in dir for a backup named name, e.g.
+// dir/blueprint-3-20250101-120000.json.
+func BackupPath(dir, name string) string {
+	return filepath.Join(ExpandPath(dir), fmt.Sprintf("%s-%s.json", name, time.Now().Format("20060102-150405")))
+}
+
 func EnsureDirectory(path string) error {
 	expandedPath := ExpandPath(path)
 	return os.MkdirAll(expandedPath, 0755)
 }
 
+// ParseDuration extends time.ParseDuration with a "d" suffix for whole days, e.g. "7d".
+func ParseDuration(s string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(s, "d"); found {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+	return d, nil
+}
+
 func TruncateString(s string, maxLength int) string {
 	if len(s) <= maxLength {
 		return s
@@ -164,3 +252,15 @@ func TruncateString(s string, maxLength int) string {
 
 	return s[:maxLength-3] + "..."
 }
+
+// MaskSecret hides all but the first four and last three characters of a secret.
+// Secrets too short to partially reveal are masked completely.
+func MaskSecret(s string) string {
+	const visiblePrefix, visibleSuffix = 4, 3
+
+	if len(s) <= visiblePrefix+visibleSuffix+1 {
+		return strings.Repeat("*", len(s))
+	}
+
+	return s[:visiblePrefix] + "****" + s[len(s)-visibleSuffix:]
+}
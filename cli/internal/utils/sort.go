@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortByField sorts a slice of structs in place by the field whose json name is given in spec. A "-"
+// prefix sorts in descending order. Strings compare case-insensitively.
+func SortByField(slice interface{}, spec string) error {
+	val := reflect.ValueOf(slice)
+	if val.Kind() != reflect.Slice || val.Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot sort %T", slice)
+	}
+
+	if err := ValidateSortField(reflect.Zero(val.Type().Elem()).Interface(), spec); err != nil {
+		return err
+	}
+
+	name, descending := strings.CutPrefix(spec, "-")
+	index := sortableFields(val.Type().Elem())[name]
+
+	less := func(i, j int) bool {
+		a, b := val.Index(i).FieldByIndex(index), val.Index(j).FieldByIndex(index)
+		if descending {
+			a, b = b, a
+		}
+		return lessValue(a, b)
+	}
+
+	sort.SliceStable(slice, less)
+	return nil
+}
+
+// ValidateSortField checks that spec names a field of item that SortByField can sort on.
+func ValidateSortField(item interface{}, spec string) error {
+	name := strings.TrimPrefix(spec, "-")
+	if _, ok := sortableFields(reflect.TypeOf(item))[name]; !ok {
+		return fmt.Errorf("invalid sort field: %s (valid: %s)", name, strings.Join(SortableFields(item), ", "))
+	}
+	return nil
+}
+
+// SortableFields returns the sorted json names of the fields of item that SortByField can sort on.
+func SortableFields(item interface{}) []string {
+	fields := sortableFields(reflect.TypeOf(item))
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func sortableFields(typ reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if field.Type == reflect.TypeOf(time.Time{}) {
+			fields[name] = field.Index
+			continue
+		}
+
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			fields[name] = field.Index
+		}
+	}
+
+	return fields
+}
+
+func lessValue(a, b reflect.Value) bool {
+	if t, ok := a.Interface().(time.Time); ok {
+		return t.Before(b.Interface().(time.Time))
+	}
+
+	switch a.Kind() {
+	case reflect.String:
+		return strings.ToLower(a.String()) < strings.ToLower(b.String())
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	}
+	return false
+}
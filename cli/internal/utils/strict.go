@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CheckKnownFields reports every key in a JSON or YAML file that the schema type does not define,
+// along with its line number. Field names come from the schema's json tags. The file itself is not
+// decoded into schema, so callers can keep passing the original data through unchanged.
+func CheckKnownFields(path string, schema interface{}) error {
+	data, err := os.ReadFile(ExpandPath(path))
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	return CheckKnownFieldsData(path, data, schema)
+}
+
+// CheckKnownFieldsData is CheckKnownFields for file contents that have already been read, such as a
+// rendered template. path is only used in error messages.
+func CheckKnownFieldsData(path string, data []byte, schema interface{}) error {
+	// YAML is a superset of JSON, so one parser gives line numbers for both formats
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var unknown []string
+	collectUnknownFields(&root, reflect.TypeOf(schema), "", &unknown)
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("%s contains unknown fields:\n  %s", path, strings.Join(unknown, "\n  "))
+	}
+
+	return nil
+}
+
+func collectUnknownFields(node *yaml.Node, typ reflect.Type, path string, unknown *[]string) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			collectUnknownFields(child, typ, path, unknown)
+		}
+		return
+	case yaml.AliasNode:
+		collectUnknownFields(node.Alias, typ, path, unknown)
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+
+		fields := jsonFieldTypes(typ)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			if key.Value == "<<" {
+				collectMergedFields(value, typ, path, unknown)
+				continue
+			}
+
+			fieldType, ok := fields[key.Value]
+			if !ok {
+				*unknown = append(*unknown, fmt.Sprintf("unknown field %q at %s (line %d)", key.Value, displayPath(path), key.Line))
+				continue
+			}
+
+			collectUnknownFields(value, fieldType, joinFieldPath(path, key.Value), unknown)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+
+		for i, item := range node.Content {
+			collectUnknownFields(item, typ.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+		}
+	}
+}
+
+// collectMergedFields checks the mappings pulled in by a YAML merge key against the enclosing struct.
+func collectMergedFields(node *yaml.Node, typ reflect.Type, path string, unknown *[]string) {
+	if node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			collectUnknownFields(item, typ, path, unknown)
+		}
+		return
+	}
+	collectUnknownFields(node, typ, path, unknown)
+}
+
+// jsonFieldTypes maps each json field name of a struct, including promoted fields of embedded
+// structs, to its type.
+func jsonFieldTypes(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name, fieldType := range jsonFieldTypes(field.Type) {
+				fields[name] = fieldType
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+func joinFieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "top level"
+	}
+	return path
+}
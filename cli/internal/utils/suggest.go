@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions caps how many close names are offered for a mistyped one.
+const maxSuggestions = 3
+
+// EditDistance returns the number of single-character insertions, deletions, substitutions, and
+// swaps of adjacent characters that turn a into b (the optimal string alignment distance). Counting
+// a swap as one edit keeps common typos such as "alpah" close to "alpha".
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prevPrev := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+
+	return prev[len(rb)]
+}
+
+// Suggest returns the candidates that are close to input, ignoring case, closest first. The threshold
+// is conservative so unrelated names are not offered: roughly one edit per three characters of
+// input, never more than three, and nothing for inputs under three characters.
+func Suggest(input string, candidates []string) []string {
+	needle := strings.ToLower(input)
+	length := len([]rune(needle))
+	if length < 3 {
+		return nil
+	}
+	limit := min(length/3, 3)
+
+	type match struct {
+		name     string
+		distance int
+	}
+
+	var matches []match
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		key := strings.ToLower(candidate)
+		if seen[key] || key == needle {
+			continue
+		}
+		seen[key] = true
+
+		if d := EditDistance(needle, key); d <= limit {
+			matches = append(matches, match{candidate, d})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].distance < matches[j].distance
+	})
+
+	var names []string
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// DidYouMean formats the close matches for input as an error suffix such as
+// " (did you mean 'web-lab'?)", or returns "" when there are none.
+func DidYouMean(input string, candidates []string) string {
+	suggestions := Suggest(input, candidates)
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		quoted[i] = "'" + s + "'"
+	}
+
+	if len(quoted) == 1 {
+		return fmt.Sprintf(" (did you mean %s?)", quoted[0])
+	}
+	return fmt.Sprintf(" (did you mean %s or %s?)", strings.Join(quoted[:len(quoted)-1], ", "), quoted[len(quoted)-1])
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "lab", b: "", want: 3},
+		{a: "", b: "lab", want: 3},
+		{a: "alpha", b: "alpha", want: 0},
+		{a: "alpha", b: "alpah", want: 1},
+		{a: "alpha", b: "alph", want: 1},
+		{a: "alpha", b: "alphas", want: 1},
+		{a: "alpha", b: "aleha", want: 1},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "web-lab", b: "lab-web", want: 4},
+		{a: "Lab", b: "lab", want: 1},
+		{a: "réseau", b: "reseau", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := EditDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("EditDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := EditDistance(tt.b, tt.a); got != tt.want {
+				t.Errorf("EditDistance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"web-lab", "Web-Lab-2", "db-lab", "red-team-range", "blue-team-range", "Alpha"}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "exact match is not suggested", input: "Alpha", want: nil},
+		{name: "exact match ignoring case is not suggested", input: "ALPHA", want: nil},
+		{name: "other names close to an exact match", input: "web-lab", want: []string{"Web-Lab-2", "db-lab"}},
+		{name: "one typo", input: "web-lbb", want: []string{"web-lab"}},
+		{name: "swapped letters", input: "alpah", want: []string{"Alpha"}},
+		{name: "closest first", input: "web-lab-", want: []string{"web-lab", "Web-Lab-2"}},
+		{name: "several close names", input: "red-team-rang", want: []string{"red-team-range"}},
+		{name: "glob pattern is matched literally", input: "web-*", want: nil},
+		{name: "glob pattern close to a name", input: "web-la*", want: []string{"web-lab"}},
+		{name: "no match", input: "production", want: nil},
+		{name: "short input", input: "wb", want: nil},
+		{name: "empty input", input: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Suggest(tt.input, candidates)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Suggest(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestLimits(t *testing.T) {
+	candidates := []string{"lab-1", "lab-2", "lab-3", "lab-4", "LAB-1"}
+
+	got := Suggest("lab-0", candidates)
+	if len(got) != maxSuggestions {
+		t.Fatalf("Suggest() = %v, want %d suggestions", got, maxSuggestions)
+	}
+
+	// Names differing only in case are offered once
+	seen := make(map[string]bool)
+	for _, name := range got {
+		key := strings.ToLower(name)
+		if seen[key] {
+			t.Errorf("Suggest() = %v, offers %q twice", got, key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		candidates []string
+		want       string
+	}{
+		{name: "one suggestion", input: "web-lbb", candidates: []string{"web-lab", "db-lab"}, want: " (did you mean 'web-lab'?)"},
+		{name: "two suggestions", input: "lab-0", candidates: []string{"lab-1", "lab-2"}, want: " (did you mean 'lab-1' or 'lab-2'?)"},
+		{name: "three suggestions", input: "lab-0", candidates: []string{"lab-1", "lab-2", "lab-3"}, want: " (did you mean 'lab-1', 'lab-2' or 'lab-3'?)"},
+		{name: "exact match", input: "web-lab", candidates: []string{"web-lab"}, want: ""},
+		{name: "no match", input: "production", candidates: []string{"web-lab", "db-lab"}, want: ""},
+		{name: "no candidates", input: "web-lab", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DidYouMean(tt.input, tt.candidates); got != tt.want {
+				t.Errorf("DidYouMean(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateVars holds the raw --var and --var-file flag values for commands that accept templated files.
+type TemplateVars struct {
+	Pairs []string
+	File  string
+}
+
+// Values merges the variables from the var file with the --var pairs. Pairs take precedence.
+func (t TemplateVars) Values() (map[string]string, error) {
+	values := make(map[string]string)
+
+	if t.File != "" {
+		var fileValues map[string]interface{}
+		if err := ReadFileAsYAML(t.File, &fileValues); err != nil {
+			return nil, err
+		}
+		for key, value := range fileValues {
+			values[key] = fmt.Sprint(value)
+		}
+	}
+
+	for _, pair := range t.Pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q (expected key=value)", pair)
+		}
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// ReadTemplatedFile reads a file and renders it as a Go text/template with vars as the data, so
+// {{.name}} is replaced with the value of the "name" variable. Every variable referenced by the file
+// must be provided; all missing names are reported together.
+func ReadTemplatedFile(path string, vars map[string]string) ([]byte, error) {
+	data, err := os.ReadFile(ExpandPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	if !bytes.Contains(data, []byte("{{")) {
+		return data, nil
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+
+	var missing []string
+	for _, name := range templateFields(tmpl.Tree.Root) {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s references undefined variables: %s (set them with --var or --var-file)", path, strings.Join(missing, ", "))
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, vars); err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", path, err)
+	}
+
+	return rendered.Bytes(), nil
+}
+
+// UnmarshalStructured decodes data as JSON or YAML based on the extension of path.
+func UnmarshalStructured(path string, data []byte, target interface{}) error {
+	ext := strings.ToLower(filepath.Ext(path))
+
+	switch ext {
+	case ".json":
+		if err := json.Unmarshal(data, target); err != nil {
+			return fmt.Errorf("failed to parse JSON from %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := DecodeYAML(data, target); err != nil {
+			return fmt.Errorf("failed to parse YAML from %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("unsupported file format: %s (supported: .json, .yaml, .yml)", ext)
+	}
+
+	return nil
+}
+
+// templateFields returns the sorted top-level variable names referenced by a template. References
+// inside range and with blocks are skipped because the dot is rebound there.
+func templateFields(node parse.Node) []string {
+	seen := make(map[string]bool)
+	collectTemplateFields(node, seen)
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func collectTemplateFields(node parse.Node, seen map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateFields(child, seen)
+		}
+	case *parse.ActionNode:
+		collectTemplateFields(n.Pipe, seen)
+	case *parse.IfNode:
+		collectTemplateFields(n.Pipe, seen)
+		collectTemplateFields(n.List, seen)
+		collectTemplateFields(n.ElseList, seen)
+	case *parse.RangeNode:
+		collectTemplateFields(n.Pipe, seen)
+		collectTemplateFields(n.ElseList, seen)
+	case *parse.WithNode:
+		collectTemplateFields(n.Pipe, seen)
+		collectTemplateFields(n.ElseList, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectTemplateFields(cmd, seen)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectTemplateFields(arg, seen)
+		}
+	case *parse.FieldNode:
+		seen[n.Ident[0]] = true
+	}
+}
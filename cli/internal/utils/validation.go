@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"net/mail"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -81,3 +82,16 @@ func ValidatePositiveInt(value int, fieldName string) error {
 	}
 	return nil
 }
+
+func ValidateURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid URL '%s': must start with http:// or https://", value)
+	}
+
+	return nil
+}
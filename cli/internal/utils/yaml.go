@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxExpandedYAMLNodes bounds alias expansion so a document of nested aliases cannot grow without
+// limit once every reference is copied out.
+const maxExpandedYAMLNodes = 1 << 20
+
+// DecodeYAML decodes YAML data into target after expanding anchors, aliases and "<<" merge keys into
+// plain nodes. Authors can reuse definitions through anchors while the decoded result, and any JSON
+// produced from it for the API, contains only concrete values.
+func DecodeYAML(data []byte, target interface{}) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return err
+	}
+
+	// An empty document leaves target untouched, as yaml.Unmarshal does
+	if root.Kind == 0 {
+		return nil
+	}
+
+	expander := &yamlExpander{}
+	resolved, err := expander.expand(&root)
+	if err != nil {
+		return err
+	}
+
+	return resolved.Decode(target)
+}
+
+type yamlExpander struct {
+	nodes int
+}
+
+// expand returns a copy of node with aliases replaced by copies of their anchored nodes and merge
+// keys folded into their mappings.
+func (e *yamlExpander) expand(node *yaml.Node) (*yaml.Node, error) {
+	e.nodes++
+	if e.nodes > maxExpandedYAMLNodes {
+		return nil, fmt.Errorf("document expands to more than %d nodes through aliases", maxExpandedYAMLNodes)
+	}
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		return e.expand(node.Alias)
+	case yaml.MappingNode:
+		return e.expandMapping(node)
+	}
+
+	expanded := *node
+	expanded.Anchor = ""
+	expanded.Content = make([]*yaml.Node, 0, len(node.Content))
+
+	for _, child := range node.Content {
+		expandedChild, err := e.expand(child)
+		if err != nil {
+			return nil, err
+		}
+		expanded.Content = append(expanded.Content, expandedChild)
+	}
+
+	return &expanded, nil
+}
+
+// expandMapping expands a mapping node. Keys written in the mapping take precedence over merged keys,
+// and when several mappings are merged the earlier ones take precedence, as the YAML merge key
+// specification requires.
+func (e *yamlExpander) expandMapping(node *yaml.Node) (*yaml.Node, error) {
+	expanded := *node
+	expanded.Anchor = ""
+	expanded.Content = make([]*yaml.Node, 0, len(node.Content))
+
+	seen := make(map[string]bool)
+	var merges []*yaml.Node
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		if key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge" {
+			merges = append(merges, value)
+			continue
+		}
+
+		expandedKey, err := e.expand(key)
+		if err != nil {
+			return nil, err
+		}
+		expandedValue, err := e.expand(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if expandedKey.Kind == yaml.ScalarNode {
+			seen[expandedKey.Value] = true
+		}
+		expanded.Content = append(expanded.Content, expandedKey, expandedValue)
+	}
+
+	for _, merge := range merges {
+		sources, err := e.mergeSources(merge)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, source := range sources {
+			for i := 0; i+1 < len(source.Content); i += 2 {
+				key, value := source.Content[i], source.Content[i+1]
+				if key.Kind == yaml.ScalarNode {
+					if seen[key.Value] {
+						continue
+					}
+					seen[key.Value] = true
+				}
+				expanded.Content = append(expanded.Content, key, value)
+			}
+		}
+	}
+
+	return &expanded, nil
+}
+
+// mergeSources returns the expanded mappings named by the value of a merge key, which must be a
+// mapping or a sequence of mappings.
+func (e *yamlExpander) mergeSources(value *yaml.Node) ([]*yaml.Node, error) {
+	expanded, err := e.expand(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expanded.Kind {
+	case yaml.MappingNode:
+		return []*yaml.Node{expanded}, nil
+	case yaml.SequenceNode:
+		for _, item := range expanded.Content {
+			if item.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("line %d: merge key list may only contain mappings", value.Line)
+			}
+		}
+		return expanded.Content, nil
+	}
+
+	return nil, fmt.Errorf("line %d: merge key value must be a mapping or a list of mappings", value.Line)
+}
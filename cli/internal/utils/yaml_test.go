@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func decodeYAMLToJSON(t *testing.T, doc string) (string, error) {
+	t.Helper()
+
+	var value interface{}
+	if err := DecodeYAML([]byte(doc), &value); err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("decoded YAML cannot be sent as JSON: %v", err)
+	}
+	return string(encoded), nil
+}
+
+func TestDecodeYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "alias",
+			doc: `
+defaults: &host {os: debian_11, spec: tiny}
+hosts: [*host, *host]
+`,
+			want: `{"defaults":{"os":"debian_11","spec":"tiny"},"hosts":[{"os":"debian_11","spec":"tiny"},{"os":"debian_11","spec":"tiny"}]}`,
+		},
+		{
+			name: "merge with override",
+			doc: `
+base: &base {os: debian_11, spec: tiny, size: 8}
+web:
+  <<: *base
+  spec: small
+`,
+			want: `{"base":{"os":"debian_11","size":8,"spec":"tiny"},"web":{"os":"debian_11","size":8,"spec":"small"}}`,
+		},
+		{
+			name: "earlier merge wins",
+			doc: `
+a: &a {os: debian_11, spec: tiny}
+b: &b {os: ubuntu_22, size: 16}
+host:
+  <<: [*a, *b]
+`,
+			want: `{"a":{"os":"debian_11","spec":"tiny"},"b":{"os":"ubuntu_22","size":16},"host":{"os":"debian_11","size":16,"spec":"tiny"}}`,
+		},
+		{
+			name: "nested merges",
+			doc: `
+base: &base {os: debian_11}
+small: &small
+  <<: *base
+  spec: small
+host:
+  <<: *small
+  hostname: web
+`,
+			want: `{"base":{"os":"debian_11"},"host":{"hostname":"web","os":"debian_11","spec":"small"},"small":{"os":"debian_11","spec":"small"}}`,
+		},
+		{
+			name: "aliased sequence",
+			doc: `
+tags: &tags [web, beginner]
+copy: *tags
+`,
+			want: `{"copy":["web","beginner"],"tags":["web","beginner"]}`,
+		},
+		{name: "plain document", doc: "name: lab\nvnc: true\n", want: `{"name":"lab","vnc":true}`},
+		{name: "merge of a scalar", doc: "x: &x 1\ny:\n  <<: *x\n", wantErr: "merge key value must be a mapping or a list of mappings"},
+		{name: "merge list with a scalar", doc: "a: &a {k: v}\ny:\n  <<: [*a, 1]\n", wantErr: "merge key list may only contain mappings"},
+		{name: "unknown alias", doc: "a: *missing\n", wantErr: "unknown anchor"},
+		{name: "syntax error", doc: "a: [unclosed\n", wantErr: "did not find expected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeYAMLToJSON(t, tt.doc)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DecodeYAML() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeYAML() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DecodeYAML() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeYAMLExpansionLimit(t *testing.T) {
+	// Each level doubles the previous one, so the expanded document has about 2^30 nodes
+	var doc strings.Builder
+	doc.WriteString("l0: &l0 [x, x]\n")
+	for i := 1; i <= 30; i++ {
+		fmt.Fprintf(&doc, "l%d: &l%d [*l%d, *l%d]\n", i, i, i-1, i-1)
+	}
+
+	var value interface{}
+	err := DecodeYAML([]byte(doc.String()), &value)
+	if err == nil || !strings.Contains(err.Error(), "expands to more than") {
+		t.Fatalf("DecodeYAML() error = %v, want the expansion limit", err)
+	}
+}
+
+func TestDecodeYAMLEmptyDocument(t *testing.T) {
+	target := map[string]interface{}{"kept": true}
+	if err := DecodeYAML([]byte("\n"), &target); err != nil {
+		t.Fatalf("DecodeYAML() error = %v", err)
+	}
+	if target["kept"] != true {
+		t.Errorf("DecodeYAML() changed the target to %v", target)
+	}
+}
+
+func TestDecodeYAMLIntoStruct(t *testing.T) {
+	type host struct {
+		Hostname string `yaml:"hostname"`
+		OS       string `yaml:"os"`
+		Spec     string `yaml:"spec"`
+	}
+
+	doc := `
+defaults: &defaults {os: debian_11, spec: tiny}
+hosts:
+  - <<: *defaults
+    hostname: web
+  - <<: *defaults
+    hostname: db
+    spec: large
+`
+	var blueprint struct {
+		Hosts []host `yaml:"hosts"`
+	}
+	if err := DecodeYAML([]byte(doc), &blueprint); err != nil {
+		t.Fatalf("DecodeYAML() error = %v", err)
+	}
+
+	want := []host{{Hostname: "web", OS: "debian_11", Spec: "tiny"}, {Hostname: "db", OS: "debian_11", Spec: "large"}}
+	if len(blueprint.Hosts) != len(want) {
+		t.Fatalf("hosts = %+v, want %+v", blueprint.Hosts, want)
+	}
+	for i := range want {
+		if blueprint.Hosts[i] != want[i] {
+			t.Errorf("host %d = %+v, want %+v", i, blueprint.Hosts[i], want[i])
+		}
+	}
+}